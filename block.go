@@ -115,6 +115,15 @@ func (block *block) reorderColumns(columns []column.ColumnBasic) ([]column.Colum
 	return columns, nil
 }
 
+func (block *block) hasColumn(name string) bool {
+	for _, c := range block.Columns {
+		if string(c.Name) == name {
+			return true
+		}
+	}
+	return false
+}
+
 func findColumn(columns []column.ColumnBasic, name []byte) (int, column.ColumnBasic) {
 	for i, col := range columns {
 		if bytes.Equal(col.Name(), name) {
@@ -9,6 +9,8 @@ import (
 	"io"
 	"net"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/vahid-sohrabloo/chconn/v2/column"
@@ -33,6 +35,8 @@ const (
 	clientQuery = 1
 	// A block of data (compressed or not).
 	clientData = 2
+	// Cancel the query execution.
+	clientCancel = 3
 	// Check that connection to the server is alive.
 	clientPing = 4
 )
@@ -56,6 +60,8 @@ const (
 	serverTotals = 7
 	// A block with minimums and maximums (compressed or not).
 	serverExtremes = 8
+	// A block with the server logs (uncompressed).
+	serverLog = 10
 	// Columns' description for default values calculation
 	serverTableColumns = 11
 	// list of unique parts ids.
@@ -123,12 +129,14 @@ type Conn interface {
 	// Insert executes a insert query and commit all columns data.
 	//
 	// If the query is successful, the columns buffer will be reset.
+	// If the query fails, the columns keep their data. so the insert can be called again with the same columns.
 	//
 	// NOTE: only use for insert query
 	Insert(ctx context.Context, query string, columns ...column.ColumnBasic) error
 	// InsertWithOption executes a insert query with the query options and commit all columns data.
 	//
 	// If the query is successful, the columns buffer will be reset.
+	// If the query fails, the columns keep their data. so the insert can be called again with the same columns.
 	//
 	// NOTE: only use for insert query
 	InsertWithOption(ctx context.Context, query string, queryOptions *QueryOptions, columns ...column.ColumnBasic) error
@@ -184,8 +192,25 @@ type conn struct {
 
 	contextWatcher *ctxwatch.ContextWatcher
 	block          *block
+	// tableColumns is the description of the table columns of the current insert query
+	tableColumns []TableColumn
+
+	// writeMu is held while writing a packet. so the Cancel packet is not written in the middle of another packet.
+	writeMu sync.Mutex
+	// cancelMu protects queryRunning and canceled.
+	cancelMu     sync.Mutex
+	queryRunning bool
+	canceled     bool
 
 	profileEvent *ProfileEvent
+	serverLog    *serverLogBlock
+	// onLog is the QueryOptions.OnLog of the current query
+	onLog func(*ServerLog)
+	// stats is the statistics of the current query
+	stats *QueryStats
+
+	// ioStat is the cumulative counters of the connection for Observer
+	ioStat ioStat
 }
 
 // Connect establishes a connection to a ClickHouse server using the environment and connString (in URL or DSN format)
@@ -244,7 +269,16 @@ func ConnectConfig(octx context.Context, config *Config) (c Conn, err error) {
 		} else {
 			ctx = octx
 		}
+		start := time.Now()
 		c, err = connect(ctx, config, fc)
+		if config.Observer != nil {
+			_, address := NetworkAddress(fc.Host, fc.Port)
+			config.Observer.OnConnect(ctx, ConnectEvent{
+				Address:  address,
+				Duration: time.Since(start),
+				Err:      err,
+			})
+		}
 		if err == nil {
 			foundBestServer = true
 			break
@@ -333,7 +367,8 @@ func connect(ctx context.Context, config *Config, fallbackConfig *FallbackConfig
 	}
 
 	c.status = connStatusConnecting
-	c.contextWatcher = ctxwatch.NewContextWatcher(
+	// there is no query to cancel while connecting. so just kill the connection.
+	connectWatcher := ctxwatch.NewContextWatcher(
 		func() {
 			c.conn.SetDeadline(time.Date(1, 1, 1, 1, 1, 1, 1, time.UTC)) //nolint:errcheck //no need
 		},
@@ -348,20 +383,28 @@ func connect(ctx context.Context, config *Config, fallbackConfig *FallbackConfig
 			return nil, newContextAlreadyDoneError(ctx)
 		default:
 		}
-		c.contextWatcher.Watch(ctx)
-		defer c.contextWatcher.Unwatch()
+		connectWatcher.Watch(ctx)
+		defer connectWatcher.Unwatch()
 	}
 
+	c.contextWatcher = ctxwatch.NewContextWatcher(
+		c.onCancel,
+		func() {
+			c.conn.SetDeadline(time.Time{}) //nolint:errcheck //no need
+		},
+	)
+
 	c.writer = readerwriter.NewWriter()
+	rw := &countingConn{rw: c.conn, stat: &c.ioStat}
 	if config.ReaderFunc != nil {
-		c.reader = readerwriter.NewReader(config.ReaderFunc(c.conn))
+		c.reader = readerwriter.NewReader(config.ReaderFunc(rw))
 	} else {
-		c.reader = readerwriter.NewReader(bufio.NewReaderSize(c.conn, c.config.MinReadBufferSize))
+		c.reader = readerwriter.NewReader(bufio.NewReaderSize(rw, c.config.MinReadBufferSize))
 	}
 	if config.WriterFunc != nil {
-		c.writerTo = config.WriterFunc(c.conn)
+		c.writerTo = config.WriterFunc(rw)
 	} else {
-		c.writerTo = c.conn
+		c.writerTo = rw
 	}
 	if c.compress {
 		c.writerToCompress = readerwriter.NewCompressWriter(c.writerTo, byte(config.Compress))
@@ -379,6 +422,7 @@ func connect(ctx context.Context, config *Config, fallbackConfig *FallbackConfig
 
 	c.block = newBlock()
 	c.profileEvent = newProfileEvent()
+	c.stats = newQueryStats()
 	c.status = connStatusIdle
 
 	return c, nil
@@ -461,11 +505,21 @@ func (ch *conn) unlock() {
 }
 
 func (ch *conn) sendQueryWithOption(
-	query,
-	queryID string,
-	settings Settings,
-	parameters *Parameters,
+	ctx context.Context,
+	query string,
+	queryOptions *QueryOptions,
 ) error {
+	queryID := queryOptions.QueryID
+	settings := queryOptions.Settings
+	parameters := queryOptions.Parameters
+	ch.writeMu.Lock()
+	defer ch.writeMu.Unlock()
+	ch.cancelMu.Lock()
+	ch.canceled = false
+	ch.cancelMu.Unlock()
+	ch.tableColumns = nil
+	ch.onLog = queryOptions.OnLog
+	ch.stats = newQueryStats()
 	ch.writer.Uvarint(clientQuery)
 	ch.writer.String(queryID)
 	if ch.serverInfo.Revision >= helper.DbmsMinRevisionWithClientInfo {
@@ -476,7 +530,7 @@ func (ch *conn) sendQueryWithOption(
 		ch.clientInfo.fillOSUserHostNameAndVersionInfo()
 		ch.clientInfo.ClientName = ch.config.Database + " " + ch.config.ClientName
 
-		ch.clientInfo.write(ch)
+		ch.clientInfo.write(ch, queryOptions.traceContext(ctx))
 	}
 
 	// setting
@@ -508,10 +562,133 @@ func (ch *conn) sendQueryWithOption(
 		return errors.New("parameters are not supported by the server")
 	}
 
-	return ch.sendEmptyBlock()
+	if err := ch.sendEmptyBlock(); err != nil {
+		return err
+	}
+	ch.cancelMu.Lock()
+	ch.queryRunning = true
+	ch.cancelMu.Unlock()
+	return nil
+}
+
+// onCancel is called by the context watcher when the context of the running query is done.
+// it asks the server to stop the query and waits at most CancelTimeout for the rest of the packets.
+// if there is no running query or a packet is being written, it kills the connection.
+func (ch *conn) onCancel() {
+	if !ch.writeMu.TryLock() {
+		ch.conn.SetDeadline(time.Date(1, 1, 1, 1, 1, 1, 1, time.UTC)) //nolint:errcheck //no need
+		return
+	}
+	defer ch.writeMu.Unlock()
+	ch.cancelMu.Lock()
+	running, canceled := ch.queryRunning, ch.canceled
+	if running {
+		ch.canceled = true
+	}
+	ch.cancelMu.Unlock()
+	if !running {
+		ch.conn.SetDeadline(time.Date(1, 1, 1, 1, 1, 1, 1, time.UTC)) //nolint:errcheck //no need
+		return
+	}
+	ch.conn.SetDeadline(time.Now().Add(ch.cancelTimeout())) //nolint:errcheck //no need
+	if !canceled {
+		ch.writerTo.Write([]byte{clientCancel}) //nolint:errcheck //the deadline closes the connection
+	}
+}
+
+// sendCancel sends the Cancel packet if there is a running query and it is not canceled yet.
+func (ch *conn) sendCancel() error {
+	ch.writeMu.Lock()
+	defer ch.writeMu.Unlock()
+	if !ch.startCancel() {
+		return nil
+	}
+	if _, err := ch.writerTo.Write([]byte{clientCancel}); err != nil {
+		return &writeError{"cancel: write packet type", err}
+	}
+	return nil
+}
+
+// startCancel marks the running query as canceled. it returns false if there is no running query to cancel.
+func (ch *conn) startCancel() bool {
+	ch.cancelMu.Lock()
+	defer ch.cancelMu.Unlock()
+	if !ch.queryRunning || ch.canceled {
+		return false
+	}
+	ch.canceled = true
+	return true
+}
+
+// isCanceled reports if the Cancel packet is sent for the current query.
+func (ch *conn) isCanceled() bool {
+	ch.cancelMu.Lock()
+	defer ch.cancelMu.Unlock()
+	return ch.canceled
+}
+
+// finishQuery marks the current query as finished. so the context watcher does not send the Cancel packet anymore.
+func (ch *conn) finishQuery() {
+	ch.cancelMu.Lock()
+	ch.queryRunning = false
+	ch.cancelMu.Unlock()
+}
+
+func (ch *conn) cancelTimeout() time.Duration {
+	if ch.config.CancelTimeout > 0 {
+		return ch.config.CancelTimeout
+	}
+	return defaultCancelTimeout
+}
+
+// cancelQuery asks the server to stop the current query and drains the remaining packets
+// until EndOfStream or Exception. so the connection can be reused.
+// the remaining data blocks are read into the columns.
+func (ch *conn) cancelQuery(columns ...column.ColumnBasic) error {
+	if err := ch.sendCancel(); err != nil {
+		return err
+	}
+	ch.conn.SetReadDeadline(time.Now().Add(ch.cancelTimeout())) //nolint:errcheck //no need
+	defer ch.conn.SetReadDeadline(time.Time{})                  //nolint:errcheck //no need
+	for {
+		ch.reader.SetCompress(false)
+		res, err := ch.receiveAndProcessData(emptyOnProgress)
+		if err != nil {
+			if ch.isCancelException(err) {
+				return nil
+			}
+			return err
+		}
+		if res == nil {
+			return nil
+		}
+		if b, ok := res.(*block); ok {
+			switch {
+			case b.NumRows == 0:
+				err = b.readColumns(ch)
+			case len(columns) != 0:
+				err = b.readColumnsData(ch, true, columns...)
+			default:
+				err = &unexpectedPacket{expected: "serverEndOfStream", actual: res}
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// isCancelException reports if err is the exception that the server sent for a cancelled query.
+func (ch *conn) isCancelException(err error) bool {
+	var chErr *ChError
+	return errors.As(err, &chErr) && ch.isCanceled()
 }
 
 func (ch *conn) sendData(block *block, numRows int) error {
+	if numRows > 0 {
+		atomic.AddInt64(&ch.ioStat.blocksSent, 1)
+		atomic.AddInt64(&ch.ioStat.rowsSent, int64(numRows))
+	}
 	ch.writer.Uvarint(clientData)
 	// name
 	ch.writer.String("")
@@ -540,10 +717,17 @@ func (ch *conn) Close() error {
 	return ch.conn.Close()
 }
 
-func (ch *conn) readTableColumn() {
-	// todo check errors
-	ch.reader.String() //nolint:errcheck //no needed
-	ch.reader.String() //nolint:errcheck //no needed
+func (ch *conn) readTableColumn() error {
+	// external table name
+	if _, err := ch.reader.String(); err != nil {
+		return &readError{"table columns: read table name", err}
+	}
+	desc, err := ch.reader.String()
+	if err != nil {
+		return &readError{"table columns: read description", err}
+	}
+	ch.tableColumns, err = parseTableColumns(desc)
+	return err
 }
 func (ch *conn) receiveAndProcessData(onProgress func(*Progress)) (interface{}, error) {
 	packet, err := ch.reader.Uvarint()
@@ -554,15 +738,25 @@ func (ch *conn) receiveAndProcessData(onProgress func(*Progress)) (interface{},
 	case serverData, serverTotals, serverExtremes:
 		ch.block.reset()
 		err = ch.block.read(ch)
+		if err == nil && ch.block.NumRows > 0 {
+			atomic.AddInt64(&ch.ioStat.blocksReceived, 1)
+			atomic.AddInt64(&ch.ioStat.rowsReceived, int64(ch.block.NumRows))
+		}
 		return ch.block, err
 	case serverProfileInfo:
 		profile := newProfile()
 
 		err = profile.read(ch)
+		if err == nil {
+			ch.stats.Profile = profile
+		}
 		return profile, err
 	case serverProgress:
 		progress := newProgress()
 		err = progress.read(ch)
+		if err == nil {
+			ch.stats.Progress.add(progress)
+		}
 		if err == nil && onProgress != nil {
 			onProgress(progress)
 			return ch.receiveAndProcessData(onProgress)
@@ -575,16 +769,29 @@ func (ch *conn) receiveAndProcessData(onProgress func(*Progress)) (interface{},
 		return &pong{}, err
 	case serverException:
 		err := &ChError{}
-		defer ch.Close()
+		ch.finishQuery()
 		if errRead := err.read(ch.reader); errRead != nil {
+			ch.Close()
 			return nil, errRead
 		}
+		// the exception is the end of a cancelled query. keep the connection for reuse
+		if !ch.isCanceled() {
+			ch.Close()
+		}
 		return nil, err
 	case serverEndOfStream:
+		ch.finishQuery()
 		return nil, nil
 
 	case serverTableColumns:
-		ch.readTableColumn()
+		if err := ch.readTableColumn(); err != nil {
+			return nil, err
+		}
+		return ch.receiveAndProcessData(onProgress)
+	case serverLog:
+		if err := ch.readServerLog(ch.onLog); err != nil {
+			return nil, err
+		}
 		return ch.receiveAndProcessData(onProgress)
 	case serverProfileEvents:
 		ch.block.reset()
@@ -601,6 +808,7 @@ func (ch *conn) receiveAndProcessData(onProgress func(*Progress)) (interface{},
 		if err != nil {
 			return nil, err
 		}
+		ch.stats.ProfileEvents.Add(ch.profileEvent)
 		return ch.profileEvent, nil
 	}
 	return nil, &notImplementedPacket{packet: packet}
@@ -622,6 +830,29 @@ type QueryOptions struct {
 	OnProfileEvent func(*ProfileEvent)
 	Parameters     *Parameters
 	UseGoTime      bool
+	// Prefetch reads and decodes the next block of a select query in a background goroutine while the current
+	// block is processed. the blocks are read into two sets of columns alternately that are built from
+	// the ClickHouse types, so no columns must be passed to Select (ErrPrefetchColumns is returned otherwise)
+	// and the columns of the current block must be get by `SelectStmt.Columns()` after each `Next`.
+	// OnProgress, OnProfile, OnProfileEvent and OnLog are called from the background goroutine.
+	Prefetch bool
+	// Idempotent marks the query safe to run more than once (e.g. an insert with insert_deduplication_token).
+	// chpool retries the idempotent Exec and Insert queries after network failures (see chpool.RetryPolicy).
+	Idempotent bool
+	// ValidateOmittedColumns checks that the columns that are omitted from an insert query have a default expression
+	// or are nullable. otherwise the insert is stopped with ColumnNotSuppliedError before sending any data.
+	// By default ClickHouse fills the omitted columns with the default value of their type.
+	// It needs the table columns description from the server (input_format_defaults_for_omitted_fields).
+	ValidateOmittedColumns bool
+	// OnStats is called with the statistics of the query when the query is finished without error.
+	// For select and insert statements it is called on Close (see also SelectStmt.Stats and InsertStmt.Stats).
+	OnStats func(*QueryStats)
+	// OnLog is called for each log entry that the server sends for the query.
+	// The server sends the logs when the send_logs_level setting is set (e.g. "debug").
+	OnLog func(*ServerLog)
+	// TraceContext is the W3C trace context that is sent to the server for the OpenTelemetry tracing of the query.
+	// If it is nil, the trace context of the context of the query is used (see ContextWithTraceContext).
+	TraceContext *TraceContext
 }
 
 func (ch *conn) Exec(ctx context.Context, query string) error {
@@ -632,14 +863,27 @@ func (ch *conn) ExecWithOption(
 	ctx context.Context,
 	query string,
 	queryOptions *QueryOptions,
+) error {
+	ctx, endQuery := ch.startQuery(ctx, OperationExec, query, queryOptions)
+	err := ch.execWithOption(ctx, query, queryOptions)
+	endQuery(err)
+	return err
+}
+
+func (ch *conn) execWithOption(
+	ctx context.Context,
+	query string,
+	queryOptions *QueryOptions,
 ) error {
 	err := ch.lock()
 	if err != nil {
 		return err
 	}
+	// keepConn is set when the query failed but the connection can still be used
+	var keepConn bool
 	defer func() {
 		ch.unlock()
-		if err != nil {
+		if err != nil && !keepConn {
 			ch.Close()
 		}
 	}()
@@ -658,7 +902,7 @@ func (ch *conn) ExecWithOption(
 		queryOptions = emptyQueryOptions
 	}
 
-	err = ch.sendQueryWithOption(query, queryOptions.QueryID, queryOptions.Settings, queryOptions.Parameters)
+	err = ch.sendQueryWithOption(ctx, query, queryOptions)
 	if err != nil {
 		return preferContextOverNetTimeoutError(ctx, err)
 	}
@@ -666,6 +910,30 @@ func (ch *conn) ExecWithOption(
 		queryOptions.OnProgress = emptyOnProgress
 	}
 
-	_, err = ch.receiveAndProcessData(queryOptions.OnProgress)
+	var res interface{}
+	for {
+		res, err = ch.receiveAndProcessData(queryOptions.OnProgress)
+		if err != nil {
+			break
+		}
+		if profile, ok := res.(*Profile); ok {
+			if queryOptions.OnProfile != nil {
+				queryOptions.OnProfile(profile)
+			}
+			continue
+		}
+		if profileEvent, ok := res.(*ProfileEvent); ok {
+			if queryOptions.OnProfileEvent != nil {
+				queryOptions.OnProfileEvent(profileEvent)
+			}
+			continue
+		}
+		break
+	}
+	if ch.isCancelException(err) {
+		// the server stopped the query. the connection can be reused
+		keepConn = true
+		return newCanceledError(ctx, err)
+	}
 	return preferContextOverNetTimeoutError(ctx, err)
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2/column"
 )
 
 func TestConnect(t *testing.T) {
@@ -243,6 +244,41 @@ func TestExecError(t *testing.T) {
 	assert.True(t, c.IsClosed())
 }
 
+func TestExecProfilePackets(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	c, err := Connect(context.Background(), connString)
+	require.NoError(t, err)
+
+	err = c.Exec(context.Background(), `DROP TABLE IF EXISTS test_exec_profile_packets`)
+	require.NoError(t, err)
+	err = c.Exec(context.Background(), `CREATE TABLE test_exec_profile_packets (id UInt64) Engine=Memory`)
+	require.NoError(t, err)
+
+	// the server sends the profile and the profile events packets before the end of the query.
+	// all of them must be read, otherwise the next query reads them.
+	var profileEvents int
+	err = c.ExecWithOption(context.Background(), `INSERT INTO test_exec_profile_packets SELECT number FROM numbers(10)`,
+		&QueryOptions{
+			OnProfileEvent: func(*ProfileEvent) {
+				profileEvents++
+			},
+		})
+	require.NoError(t, err)
+	assert.Positive(t, profileEvents)
+
+	col := column.New[uint64]()
+	stmt, err := c.Select(context.Background(), `SELECT count() FROM test_exec_profile_packets`, col)
+	require.NoError(t, err)
+	require.True(t, stmt.Next())
+	require.NoError(t, stmt.Err())
+	assert.Equal(t, uint64(10), col.Row(0))
+	stmt.Close()
+	c.Close()
+}
+
 func TestExecCtxError(t *testing.T) {
 	t.Parallel()
 
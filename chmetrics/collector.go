@@ -0,0 +1,336 @@
+// Package chmetrics provides a collector of the metrics of chconn and chpool
+// that is exported in the Prometheus text format.
+package chmetrics
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/vahid-sohrabloo/chconn/v2"
+	"github.com/vahid-sohrabloo/chconn/v2/chpool"
+)
+
+// DefaultBuckets is the default buckets (in seconds) of the query latency histograms.
+var DefaultBuckets = []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60}
+
+// Collector is a chconn.Observer that collects the metrics of the connections and the queries.
+// The metrics and the statistics of the added pools are exported in the Prometheus text format
+// by WriteTo and ServeHTTP.
+//
+// To collect the metrics of a pool, set it as the observer of the connection config and add the pool:
+//
+//	collector := chmetrics.NewCollector("clickhouse")
+//	config.ConnConfig.Observer = collector
+//	pool, err := chpool.NewWithConfig(config)
+//	collector.AddPool("default", pool)
+//	http.Handle("/metrics", collector)
+type Collector struct {
+	namespace string
+	buckets   []float64
+
+	mu            sync.Mutex
+	connects      int64
+	connectErrors int64
+	inFlight      int64
+	queries       map[string]*queryMetrics
+	pools         []namedPool
+}
+
+type namedPool struct {
+	name string
+	pool chpool.Pool
+}
+
+// queryMetrics is the metrics of the queries of an operation.
+type queryMetrics struct {
+	count          int64
+	errors         int64
+	bytesSent      int64
+	bytesReceived  int64
+	blocksSent     int64
+	rowsSent       int64
+	blocksReceived int64
+	rowsReceived   int64
+	// bucketCounts is the number of the queries in each bucket (not cumulative)
+	bucketCounts []int64
+	durationSum  float64
+}
+
+var _ chconn.Observer = &Collector{}
+
+// NewCollector returns a new collector. namespace is the prefix of the metric names ("chconn" if it is empty).
+// buckets are the upper bounds (in seconds) of the query latency histograms (DefaultBuckets if it is empty).
+func NewCollector(namespace string, buckets ...float64) *Collector {
+	if namespace == "" {
+		namespace = "chconn"
+	}
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &Collector{
+		namespace: namespace,
+		buckets:   buckets,
+		queries:   make(map[string]*queryMetrics),
+	}
+}
+
+// AddPool adds the statistics of a pool to the exported metrics with the label pool=name.
+func (c *Collector) AddPool(name string, p chpool.Pool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pools = append(c.pools, namedPool{name: name, pool: p})
+}
+
+// OnConnect implements chconn.Observer.
+func (c *Collector) OnConnect(ctx context.Context, event chconn.ConnectEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.connects++
+	if event.Err != nil {
+		c.connectErrors++
+	}
+}
+
+// OnQueryStart implements chconn.Observer.
+func (c *Collector) OnQueryStart(ctx context.Context, event chconn.QueryStartEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inFlight++
+}
+
+// OnQueryEnd implements chconn.Observer.
+func (c *Collector) OnQueryEnd(ctx context.Context, event chconn.QueryEndEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inFlight--
+	m, ok := c.queries[event.Operation]
+	if !ok {
+		m = &queryMetrics{
+			bucketCounts: make([]int64, len(c.buckets)),
+		}
+		c.queries[event.Operation] = m
+	}
+	m.count++
+	if event.Err != nil {
+		m.errors++
+	}
+	m.bytesSent += event.BytesSent
+	m.bytesReceived += event.BytesReceived
+	m.blocksSent += event.BlocksSent
+	m.rowsSent += event.RowsSent
+	m.blocksReceived += event.BlocksReceived
+	m.rowsReceived += event.RowsReceived
+	seconds := event.Duration.Seconds()
+	m.durationSum += seconds
+	if i := sort.SearchFloat64s(c.buckets, seconds); i < len(c.buckets) {
+		m.bucketCounts[i]++
+	}
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.WriteTo(w) //nolint:errcheck //the client is gone
+}
+
+// WriteTo writes the metrics in the Prometheus text format to w.
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	c.mu.Lock()
+	c.writeConnMetrics(&buf)
+	pools := append([]namedPool(nil), c.pools...)
+	c.mu.Unlock()
+	// Stat locks the pool. so it is not called with the lock of the collector held.
+	c.writePoolMetrics(&buf, pools)
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+func (c *Collector) writeConnMetrics(buf *bytes.Buffer) {
+	e := &encoder{buf: buf, namespace: c.namespace}
+	e.header("connects_total", "counter", "The number of the connection attempts.")
+	e.sample("connects_total", nil, float64(c.connects))
+	e.header("connect_errors_total", "counter", "The number of the failed connection attempts.")
+	e.sample("connect_errors_total", nil, float64(c.connectErrors))
+	e.header("queries_in_flight", "gauge", "The number of the running queries.")
+	e.sample("queries_in_flight", nil, float64(c.inFlight))
+
+	operations := make([]string, 0, len(c.queries))
+	for op := range c.queries {
+		operations = append(operations, op)
+	}
+	sort.Strings(operations)
+	counters := []struct {
+		name  string
+		help  string
+		value func(m *queryMetrics) int64
+	}{
+		{"queries_total", "The number of the queries.", func(m *queryMetrics) int64 { return m.count }},
+		{"query_errors_total", "The number of the failed queries.", func(m *queryMetrics) int64 { return m.errors }},
+		{"bytes_sent_total", "The number of the bytes sent to the server.",
+			func(m *queryMetrics) int64 { return m.bytesSent }},
+		{"bytes_received_total", "The number of the bytes received from the server.",
+			func(m *queryMetrics) int64 { return m.bytesReceived }},
+		{"blocks_sent_total", "The number of the data blocks sent to the server.",
+			func(m *queryMetrics) int64 { return m.blocksSent }},
+		{"rows_sent_total", "The number of the rows sent to the server.",
+			func(m *queryMetrics) int64 { return m.rowsSent }},
+		{"blocks_received_total", "The number of the data blocks received from the server.",
+			func(m *queryMetrics) int64 { return m.blocksReceived }},
+		{"rows_received_total", "The number of the rows received from the server.",
+			func(m *queryMetrics) int64 { return m.rowsReceived }},
+	}
+	for _, counter := range counters {
+		e.header(counter.name, "counter", counter.help)
+		for _, op := range operations {
+			e.sample(counter.name, []string{"operation", op}, float64(counter.value(c.queries[op])))
+		}
+	}
+
+	e.header("query_duration_seconds", "histogram", "The latency of the queries.")
+	for _, op := range operations {
+		m := c.queries[op]
+		var cumulative int64
+		for i, le := range c.buckets {
+			cumulative += m.bucketCounts[i]
+			e.sample("query_duration_seconds_bucket",
+				[]string{"operation", op, "le", strconv.FormatFloat(le, 'g', -1, 64)}, float64(cumulative))
+		}
+		e.sample("query_duration_seconds_bucket", []string{"operation", op, "le", "+Inf"}, float64(m.count))
+		e.sample("query_duration_seconds_sum", []string{"operation", op}, m.durationSum)
+		e.sample("query_duration_seconds_count", []string{"operation", op}, float64(m.count))
+	}
+}
+
+func (c *Collector) writePoolMetrics(buf *bytes.Buffer, pools []namedPool) {
+	if len(pools) == 0 {
+		return
+	}
+	stats := make([]*chpool.Stat, len(pools))
+	for i, p := range pools {
+		stats[i] = p.pool.Stat()
+	}
+	e := &encoder{buf: buf, namespace: c.namespace}
+	poolMetrics := []struct {
+		name  string
+		typ   string
+		help  string
+		value func(s *chpool.Stat) float64
+	}{
+		{"pool_acquire_total", "counter", "The number of the successful acquires from the pool.",
+			func(s *chpool.Stat) float64 { return float64(s.AcquireCount()) }},
+		{"pool_acquire_duration_seconds_total", "counter", "The total duration of the successful acquires.",
+			func(s *chpool.Stat) float64 { return s.AcquireDuration().Seconds() }},
+		{"pool_canceled_acquire_total", "counter", "The number of the acquires canceled by a context.",
+			func(s *chpool.Stat) float64 { return float64(s.CanceledAcquireCount()) }},
+		{"pool_empty_acquire_total", "counter", "The number of the acquires that waited for a connection.",
+			func(s *chpool.Stat) float64 { return float64(s.EmptyAcquireCount()) }},
+		{"pool_new_conns_total", "counter", "The number of the new connections opened.",
+			func(s *chpool.Stat) float64 { return float64(s.NewConnsCount()) }},
+		{"pool_max_lifetime_destroy_total", "counter", "The number of the connections destroyed by MaxConnLifetime.",
+			func(s *chpool.Stat) float64 { return float64(s.MaxLifetimeDestroyCount()) }},
+		{"pool_max_idle_destroy_total", "counter", "The number of the connections destroyed by MaxConnIdleTime.",
+			func(s *chpool.Stat) float64 { return float64(s.MaxIdleDestroyCount()) }},
+		{"pool_acquired_conns", "gauge", "The number of the acquired connections.",
+			func(s *chpool.Stat) float64 { return float64(s.AcquiredConns()) }},
+		{"pool_constructing_conns", "gauge", "The number of the connections being constructed.",
+			func(s *chpool.Stat) float64 { return float64(s.ConstructingConns()) }},
+		{"pool_idle_conns", "gauge", "The number of the idle connections.",
+			func(s *chpool.Stat) float64 { return float64(s.IdleConns()) }},
+		{"pool_total_conns", "gauge", "The total number of the connections.",
+			func(s *chpool.Stat) float64 { return float64(s.TotalConns()) }},
+		{"pool_max_conns", "gauge", "The maximum size of the pool.",
+			func(s *chpool.Stat) float64 { return float64(s.MaxConns()) }},
+	}
+	for _, metric := range poolMetrics {
+		e.header(metric.name, metric.typ, metric.help)
+		for i, p := range pools {
+			e.sample(metric.name, []string{"pool", p.name}, metric.value(stats[i]))
+		}
+	}
+
+	hostMetrics := []struct {
+		name  string
+		typ   string
+		help  string
+		value func(h *chpool.HostStat) float64
+	}{
+		{"pool_host_conns", "gauge", "The number of the open connections to the host.",
+			func(h *chpool.HostStat) float64 { return float64(h.Conns) }},
+		{"pool_host_connects_total", "counter", "The number of the connections opened to the host.",
+			func(h *chpool.HostStat) float64 { return float64(h.ConnectCount) }},
+		{"pool_host_failures_total", "counter", "The number of the connect and query failures of the host.",
+			func(h *chpool.HostStat) float64 { return float64(h.FailureCount) }},
+		{"pool_host_healthy", "gauge", "1 if the host is not ejected.",
+			func(h *chpool.HostStat) float64 {
+				if h.Healthy() {
+					return 1
+				}
+				return 0
+			}},
+	}
+	for _, metric := range hostMetrics {
+		e.header(metric.name, metric.typ, metric.help)
+		for i, p := range pools {
+			hosts := stats[i].Hosts()
+			for j := range hosts {
+				e.sample(metric.name, []string{"pool", p.name, "host", hosts[j].Address}, metric.value(&hosts[j]))
+			}
+		}
+	}
+}
+
+// encoder writes the metrics in the Prometheus text format.
+type encoder struct {
+	buf       *bytes.Buffer
+	namespace string
+}
+
+func (e *encoder) header(name, typ, help string) {
+	e.buf.WriteString("# HELP ")
+	e.buf.WriteString(e.namespace)
+	e.buf.WriteByte('_')
+	e.buf.WriteString(name)
+	e.buf.WriteByte(' ')
+	e.buf.WriteString(help)
+	e.buf.WriteString("\n# TYPE ")
+	e.buf.WriteString(e.namespace)
+	e.buf.WriteByte('_')
+	e.buf.WriteString(name)
+	e.buf.WriteByte(' ')
+	e.buf.WriteString(typ)
+	e.buf.WriteByte('\n')
+}
+
+// sample writes a sample. labels is the pairs of the label names and values.
+func (e *encoder) sample(name string, labels []string, value float64) {
+	e.buf.WriteString(e.namespace)
+	e.buf.WriteByte('_')
+	e.buf.WriteString(name)
+	if len(labels) > 0 {
+		e.buf.WriteByte('{')
+		for i := 0; i < len(labels); i += 2 {
+			if i > 0 {
+				e.buf.WriteByte(',')
+			}
+			e.buf.WriteString(labels[i])
+			e.buf.WriteString(`="`)
+			e.buf.WriteString(labelValueEscaper.Replace(labels[i+1]))
+			e.buf.WriteByte('"')
+		}
+		e.buf.WriteByte('}')
+	}
+	e.buf.WriteByte(' ')
+	e.buf.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+	e.buf.WriteByte('\n')
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
@@ -0,0 +1,99 @@
+package chmetrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2"
+	"github.com/vahid-sohrabloo/chconn/v2/chpool"
+)
+
+func TestCollector(t *testing.T) {
+	t.Parallel()
+
+	c := NewCollector("", 0.1, 1)
+	ctx := context.Background()
+	c.OnConnect(ctx, chconn.ConnectEvent{Address: "localhost:9000"})
+	c.OnConnect(ctx, chconn.ConnectEvent{Address: "localhost:9001", Err: errors.New("refused")})
+	c.OnQueryStart(ctx, chconn.QueryStartEvent{Operation: chconn.OperationSelect})
+	c.OnQueryStart(ctx, chconn.QueryStartEvent{Operation: chconn.OperationSelect})
+	c.OnQueryEnd(ctx, chconn.QueryEndEvent{
+		Operation:      chconn.OperationSelect,
+		Duration:       50 * time.Millisecond,
+		BytesReceived:  100,
+		BlocksReceived: 2,
+		RowsReceived:   10,
+	})
+	c.OnQueryStart(ctx, chconn.QueryStartEvent{Operation: chconn.OperationInsert})
+	c.OnQueryEnd(ctx, chconn.QueryEndEvent{
+		Operation: chconn.OperationInsert,
+		Duration:  2 * time.Second,
+		Err:       errors.New("failed"),
+	})
+
+	var buf bytes.Buffer
+	_, err := c.WriteTo(&buf)
+	require.NoError(t, err)
+	out := buf.String()
+	for _, line := range []string{
+		"# TYPE chconn_connects_total counter\n",
+		"chconn_connects_total 2\n",
+		"chconn_connect_errors_total 1\n",
+		"chconn_queries_in_flight 1\n",
+		`chconn_queries_total{operation="Insert"} 1` + "\n",
+		`chconn_queries_total{operation="Select"} 1` + "\n",
+		`chconn_query_errors_total{operation="Insert"} 1` + "\n",
+		`chconn_query_errors_total{operation="Select"} 0` + "\n",
+		`chconn_bytes_received_total{operation="Select"} 100` + "\n",
+		`chconn_blocks_received_total{operation="Select"} 2` + "\n",
+		`chconn_rows_received_total{operation="Select"} 10` + "\n",
+		"# TYPE chconn_query_duration_seconds histogram\n",
+		`chconn_query_duration_seconds_bucket{operation="Select",le="0.1"} 1` + "\n",
+		`chconn_query_duration_seconds_bucket{operation="Insert",le="1"} 0` + "\n",
+		`chconn_query_duration_seconds_bucket{operation="Insert",le="+Inf"} 1` + "\n",
+		`chconn_query_duration_seconds_sum{operation="Insert"} 2` + "\n",
+		`chconn_query_duration_seconds_count{operation="Select"} 1` + "\n",
+	} {
+		assert.Contains(t, out, line)
+	}
+	assert.NotContains(t, out, "chconn_pool_")
+
+	var e encoder
+	e.buf = &buf
+	e.namespace = "test"
+	buf.Reset()
+	e.sample("label", []string{"name", "a\"b\\c\nd"}, 1.5)
+	assert.Equal(t, `test_label{name="a\"b\\c\nd"} 1.5`+"\n", buf.String())
+}
+
+func TestCollectorPool(t *testing.T) {
+	t.Parallel()
+
+	config, err := chpool.ParseConfig(os.Getenv("CHX_TEST_TCP_CONN_STRING"))
+	require.NoError(t, err)
+	c := NewCollector("clickhouse")
+	config.ConnConfig.Observer = c
+	p, err := chpool.NewWithConfig(config)
+	require.NoError(t, err)
+	defer p.Close()
+	c.AddPool("test", p)
+
+	require.NoError(t, p.Exec(context.Background(), "DROP TABLE IF EXISTS test_collector_not_exists"))
+
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	out := rec.Body.String()
+	assert.Contains(t, out, `clickhouse_queries_total{operation="Exec"} 1`+"\n")
+	assert.Contains(t, out, `clickhouse_query_errors_total{operation="Exec"} 0`+"\n")
+	assert.Contains(t, out, `clickhouse_pool_total_conns{pool="test"} 1`+"\n")
+	assert.Contains(t, out, "clickhouse_connect_errors_total 0\n")
+	assert.Contains(t, out, `clickhouse_pool_host_healthy{pool="test",host=`)
+}
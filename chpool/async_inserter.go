@@ -0,0 +1,399 @@
+package chpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/vahid-sohrabloo/chconn/v2"
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+)
+
+var defaultAsyncMaxRows = 100000
+var defaultAsyncMaxAge = time.Second
+var defaultAsyncFlushTimeout = time.Minute
+
+// ErrAsyncInserterClosed is returned when append to a closed AsyncInserter
+var ErrAsyncInserterClosed = errors.New("async inserter is closed")
+
+// ErrAsyncInserterNumRow is returned when appendRows does not append the same number of rows to all the columns
+var ErrAsyncInserterNumRow = errors.New("async inserter: the columns have different number of rows")
+
+// AsyncInserterConfig is the configuration of AsyncInserter. zero values use the defaults.
+type AsyncInserterConfig struct {
+	// MaxRows is the number of rows that triggers the flush of a batch. Default 100000.
+	MaxRows int
+	// MaxBytes is the size of rows (sum of the sizes passed to Append) that triggers the flush of a batch.
+	// Zero disables the size threshold.
+	MaxBytes int
+	// MaxAge is the maximum time that a row waits in the buffer before flush. Default 1s.
+	MaxAge time.Duration
+	// FlushTimeout is the timeout of inserting a batch. Default 1m.
+	FlushTimeout time.Duration
+	// MaxInflight is the maximum number of the batches that are inserted at the same time.
+	// Append (and Flush) blocks until a batch is inserted when the limit is reached. Default MaxConns of the pool.
+	MaxInflight int
+	// QueryOptions is used for the insert queries.
+	QueryOptions *chconn.QueryOptions
+	// OnFlush is called after each batch is inserted (or failed). It is called from a background goroutine.
+	OnFlush func(result BatchResult)
+}
+
+// BatchResult is the result of inserting a batch by AsyncInserter
+type BatchResult struct {
+	Query    string
+	Rows     int
+	Bytes    int
+	Duration time.Duration
+	Err      error
+}
+
+// AsyncInserter buffers the rows of the insert queries from many goroutines and inserts them in batches.
+type AsyncInserter interface {
+	// Append appends rows to the buffer of the insert query.
+	//
+	// appendRows is called with the buffered columns of the query while holding the lock of the buffer.
+	// It must append the same number of rows to all the columns and must not keep the columns.
+	// If appendRows panics, the lock is released and the panic is propagated to the caller.
+	// If appendRows panics or appends different number of rows to the columns, the buffered rows of the query
+	// are discarded (ErrAsyncInserterNumRow is returned for the different number of rows).
+	// The columns are built from the header of the insert query (see `chconn.InsertStmt.NewColumns()`)
+	// and ctx is only used to get the header for the first time.
+	//
+	// size is the approximate size of the appended rows in bytes. It is only used for the MaxBytes threshold.
+	Append(ctx context.Context, query string, size int, appendRows func(columns []column.ColumnBasic)) error
+	// Flush inserts all the buffered rows and waits for all the batches to be inserted.
+	Flush(ctx context.Context) error
+	// Close flushes all the buffered rows and rejects future Append calls.
+	Close(ctx context.Context) error
+}
+
+type asyncInserter struct {
+	pool   Pool
+	config AsyncInserterConfig
+
+	mu       sync.Mutex
+	idle     *sync.Cond
+	tables   map[string]*asyncTable
+	inflight int
+	closed   bool
+	// sem limits the number of the batches that are inserted at the same time
+	sem chan struct{}
+}
+
+type asyncTable struct {
+	// headerMu serializes getting the header of the insert query. it is not held with mu.
+	headerMu sync.Mutex
+	// header builds the columns of a new batch. it is nil until the header is received.
+	header func() ([]column.ColumnBasic, error)
+
+	mu      sync.Mutex
+	query   string
+	columns []column.ColumnBasic
+	// spare is the columns of the inserted batches that can be reused
+	spare [][]column.ColumnBasic
+	rows  int
+	bytes int
+	start time.Time
+	timer *time.Timer
+	// gen changes on each flush to ignore the timers of the flushed batches
+	gen uint64
+}
+
+type asyncBatch struct {
+	table   *asyncTable
+	columns []column.ColumnBasic
+	rows    int
+	bytes   int
+}
+
+// NewAsyncInserter creates a new AsyncInserter that inserts the batches with the given pool.
+func NewAsyncInserter(pool Pool, config AsyncInserterConfig) AsyncInserter {
+	if config.MaxRows <= 0 {
+		config.MaxRows = defaultAsyncMaxRows
+	}
+	if config.MaxAge <= 0 {
+		config.MaxAge = defaultAsyncMaxAge
+	}
+	if config.FlushTimeout <= 0 {
+		config.FlushTimeout = defaultAsyncFlushTimeout
+	}
+	if config.MaxInflight <= 0 {
+		config.MaxInflight = int(pool.Config().MaxConns)
+	}
+	a := &asyncInserter{
+		pool:   pool,
+		config: config,
+		tables: make(map[string]*asyncTable),
+		sem:    make(chan struct{}, config.MaxInflight),
+	}
+	a.idle = sync.NewCond(&a.mu)
+	return a
+}
+
+func (a *asyncInserter) Append(
+	ctx context.Context,
+	query string,
+	size int,
+	appendRows func(columns []column.ColumnBasic),
+) error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return ErrAsyncInserterClosed
+	}
+	t, ok := a.tables[query]
+	if !ok {
+		t = &asyncTable{query: query}
+		a.tables[query] = t
+	}
+	a.mu.Unlock()
+
+	if err := a.getHeader(ctx, t); err != nil {
+		return err
+	}
+	batch, err := a.appendTable(t, size, appendRows)
+	if err != nil {
+		return err
+	}
+	if batch != nil {
+		a.insertAsync(batch)
+	}
+	return nil
+}
+
+// getHeader gets the header of the insert query once per table.
+// the columns of the header are used for the first batch.
+func (a *asyncInserter) getHeader(ctx context.Context, t *asyncTable) error {
+	t.headerMu.Lock()
+	defer t.headerMu.Unlock()
+	if t.header != nil {
+		return nil
+	}
+	stmt, err := a.pool.InsertStreamWithOption(ctx, t.query, a.config.QueryOptions)
+	if err != nil {
+		return err
+	}
+	columns, err := stmt.Columns()
+	if err != nil {
+		stmt.Close()
+		return err
+	}
+	// finish the insert query without any data. so the connection can be reused
+	if err := stmt.Flush(ctx); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	t.header = stmt.NewColumns
+	t.spare = append(t.spare, columns)
+	t.mu.Unlock()
+	return nil
+}
+
+// appendTable appends the rows to the buffer of the table and returns the batch if a threshold is reached.
+func (a *asyncInserter) appendTable(
+	t *asyncTable,
+	size int,
+	appendRows func(columns []column.ColumnBasic),
+) (*asyncBatch, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.columns == nil {
+		columns, err := t.newColumns()
+		if err != nil {
+			return nil, err
+		}
+		t.columns = columns
+	}
+	appended := false
+	defer func() {
+		if !appended {
+			// appendRows panicked. the columns can have different number of rows
+			t.discard()
+		}
+	}()
+	appendRows(t.columns)
+	appended = true
+	if !t.sameNumRow() {
+		t.discard()
+		return nil, ErrAsyncInserterNumRow
+	}
+	if len(t.columns) != 0 {
+		t.rows = t.columns[0].NumRow()
+	}
+	t.bytes += size
+	if t.timer == nil && t.rows > 0 {
+		gen := t.gen
+		t.start = time.Now()
+		t.timer = time.AfterFunc(a.config.MaxAge, func() {
+			a.flushTable(t, gen)
+		})
+	}
+	if t.rows >= a.config.MaxRows || (a.config.MaxBytes > 0 && t.bytes >= a.config.MaxBytes) {
+		return t.take(), nil
+	}
+	return nil, nil
+}
+
+// newColumns returns the columns for a new batch. it reuses the columns of the inserted batches
+// or builds them from the header of the insert query.
+// the lock of the table must be held.
+func (t *asyncTable) newColumns() ([]column.ColumnBasic, error) {
+	if len(t.spare) != 0 {
+		columns := t.spare[len(t.spare)-1]
+		t.spare = t.spare[:len(t.spare)-1]
+		return columns, nil
+	}
+	return t.header()
+}
+
+// sameNumRow reports if all the columns have the same number of rows.
+// the lock of the table must be held.
+func (t *asyncTable) sameNumRow() bool {
+	for _, col := range t.columns {
+		if col.NumRow() != t.columns[0].NumRow() {
+			return false
+		}
+	}
+	return true
+}
+
+// discard drops the buffered rows. the columns are reset to be reused.
+// the lock of the table must be held.
+func (t *asyncTable) discard() {
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+	t.gen++
+	for _, col := range t.columns {
+		col.Reset()
+	}
+	t.rows = 0
+	t.bytes = 0
+}
+
+// take returns the buffered rows as a batch and starts a new batch.
+// the lock of the table must be held.
+func (t *asyncTable) take() *asyncBatch {
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+	t.gen++
+	if t.rows == 0 {
+		return nil
+	}
+	batch := &asyncBatch{
+		table:   t,
+		columns: t.columns,
+		rows:    t.rows,
+		bytes:   t.bytes,
+	}
+	t.columns = nil
+	t.rows = 0
+	t.bytes = 0
+	return batch
+}
+
+func (a *asyncInserter) flushTable(t *asyncTable, gen uint64) {
+	t.mu.Lock()
+	if t.gen != gen {
+		t.mu.Unlock()
+		return
+	}
+	batch := t.take()
+	t.mu.Unlock()
+	if batch != nil {
+		a.insertAsync(batch)
+	}
+}
+
+// insertAsync inserts the batch in a new goroutine. it blocks while MaxInflight batches are being inserted.
+func (a *asyncInserter) insertAsync(batch *asyncBatch) {
+	a.sem <- struct{}{}
+	a.mu.Lock()
+	a.inflight++
+	a.mu.Unlock()
+	go func() {
+		a.insert(batch)
+		<-a.sem
+		a.mu.Lock()
+		a.inflight--
+		if a.inflight == 0 {
+			a.idle.Broadcast()
+		}
+		a.mu.Unlock()
+	}()
+}
+
+func (a *asyncInserter) insert(batch *asyncBatch) {
+	ctx, cancel := context.WithTimeout(context.Background(), a.config.FlushTimeout)
+	defer cancel()
+	start := time.Now()
+	err := a.pool.InsertWithOption(ctx, batch.table.query, a.config.QueryOptions, batch.columns...)
+
+	t := batch.table
+	t.mu.Lock()
+	for _, col := range batch.columns {
+		col.Reset()
+	}
+	t.spare = append(t.spare, batch.columns)
+	t.mu.Unlock()
+
+	if a.config.OnFlush != nil {
+		a.config.OnFlush(BatchResult{
+			Query:    t.query,
+			Rows:     batch.rows,
+			Bytes:    batch.bytes,
+			Duration: time.Since(start),
+			Err:      err,
+		})
+	}
+}
+
+func (a *asyncInserter) Flush(ctx context.Context) error {
+	a.mu.Lock()
+	tables := make([]*asyncTable, 0, len(a.tables))
+	for _, t := range a.tables {
+		tables = append(tables, t)
+	}
+	a.mu.Unlock()
+
+	for _, t := range tables {
+		t.mu.Lock()
+		batch := t.take()
+		t.mu.Unlock()
+		if batch != nil {
+			a.insertAsync(batch)
+		}
+	}
+	return a.wait(ctx)
+}
+
+// wait waits for all the batches to be inserted.
+func (a *asyncInserter) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		a.mu.Lock()
+		for a.inflight > 0 {
+			a.idle.Wait()
+		}
+		a.mu.Unlock()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *asyncInserter) Close(ctx context.Context) error {
+	a.mu.Lock()
+	a.closed = true
+	a.mu.Unlock()
+	return a.Flush(ctx)
+}
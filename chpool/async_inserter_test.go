@@ -0,0 +1,253 @@
+package chpool
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2"
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+)
+
+func TestAsyncInserter(t *testing.T) {
+	t.Parallel()
+
+	pool, err := New(os.Getenv("CHX_TEST_TCP_CONN_STRING"))
+	require.NoError(t, err)
+	defer pool.Close()
+
+	err = pool.Exec(context.Background(), `DROP TABLE IF EXISTS clickhouse_test_async_inserter`)
+	require.NoError(t, err)
+	err = pool.Exec(context.Background(), `CREATE TABLE clickhouse_test_async_inserter (
+				id UInt64,
+				name String
+			) Engine=Memory`)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var results []BatchResult
+	inserter := NewAsyncInserter(pool, AsyncInserterConfig{
+		MaxRows: 1000,
+		MaxAge:  100 * time.Millisecond,
+		OnFlush: func(result BatchResult) {
+			mu.Lock()
+			results = append(results, result)
+			mu.Unlock()
+		},
+	})
+
+	const query = `INSERT INTO clickhouse_test_async_inserter (id, name) VALUES`
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				err := inserter.Append(context.Background(), query, 16, func(columns []column.ColumnBasic) {
+					columns[0].(*column.Base[uint64]).Append(uint64(g*1000 + i))
+					columns[1].(*column.String).Append("name")
+				})
+				assert.NoError(t, err)
+			}
+		}(g)
+	}
+	wg.Wait()
+	require.NoError(t, inserter.Close(context.Background()))
+	require.ErrorIs(t, inserter.Append(context.Background(), query, 0, func([]column.ColumnBasic) {}), ErrAsyncInserterClosed)
+
+	var rows int
+	mu.Lock()
+	for _, result := range results {
+		require.NoError(t, result.Err)
+		assert.Equal(t, query, result.Query)
+		assert.LessOrEqual(t, result.Rows, 1000)
+		rows += result.Rows
+	}
+	mu.Unlock()
+	assert.Equal(t, 8000, rows)
+
+	colCount := column.New[uint64]()
+	stmt, err := pool.Select(context.Background(), `SELECT count() FROM clickhouse_test_async_inserter`, colCount)
+	require.NoError(t, err)
+	require.True(t, stmt.Next())
+	assert.Equal(t, uint64(8000), colCount.Row(0))
+	stmt.Close()
+
+	// flush by age
+	inserter = NewAsyncInserter(pool, AsyncInserterConfig{
+		MaxAge: 50 * time.Millisecond,
+	})
+	err = inserter.Append(context.Background(), query, 16, func(columns []column.ColumnBasic) {
+		columns[0].(*column.Base[uint64]).Append(1)
+		columns[1].(*column.String).Append("age")
+	})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		stmt, err := pool.Select(context.Background(),
+			`SELECT count() FROM clickhouse_test_async_inserter WHERE name = 'age'`, colCount)
+		if err != nil {
+			return false
+		}
+		defer stmt.Close()
+		return stmt.Next() && colCount.Row(0) == 1
+	}, 5*time.Second, 50*time.Millisecond)
+	require.NoError(t, inserter.Close(context.Background()))
+}
+
+// asyncTestPool is a pool that only supports the insert queries of AsyncInserter.
+type asyncTestPool struct {
+	Pool
+	insert func(columns []column.ColumnBasic)
+	// numColumns is the number of UInt64 columns of the header. default 1
+	numColumns int
+	// headers is the number of the insert queries that are sent to get the header
+	headers int64
+}
+
+type asyncTestStmt struct {
+	chconn.InsertStmt
+	numColumns int
+}
+
+func (p *asyncTestPool) Config() *Config {
+	return &Config{MaxConns: 4}
+}
+
+func (p *asyncTestPool) InsertStreamWithOption(
+	ctx context.Context,
+	query string,
+	queryOptions *chconn.QueryOptions,
+) (chconn.InsertStmt, error) {
+	atomic.AddInt64(&p.headers, 1)
+	numColumns := p.numColumns
+	if numColumns == 0 {
+		numColumns = 1
+	}
+	return &asyncTestStmt{numColumns: numColumns}, nil
+}
+
+func (p *asyncTestPool) InsertWithOption(
+	ctx context.Context,
+	query string,
+	queryOptions *chconn.QueryOptions,
+	columns ...column.ColumnBasic,
+) error {
+	p.insert(columns)
+	return nil
+}
+
+func (s *asyncTestStmt) Columns() ([]column.ColumnBasic, error) {
+	return s.NewColumns()
+}
+
+func (s *asyncTestStmt) NewColumns() ([]column.ColumnBasic, error) {
+	columns := make([]column.ColumnBasic, s.numColumns)
+	for i := range columns {
+		columns[i] = column.New[uint64]()
+	}
+	return columns, nil
+}
+
+func (s *asyncTestStmt) Flush(ctx context.Context) error {
+	return nil
+}
+
+func TestAsyncInserterPanic(t *testing.T) {
+	t.Parallel()
+
+	var rows int64
+	inserter := NewAsyncInserter(&asyncTestPool{
+		insert: func(columns []column.ColumnBasic) {
+			assert.Equal(t, columns[0].NumRow(), columns[1].NumRow())
+			atomic.AddInt64(&rows, int64(columns[0].NumRow()))
+		},
+		numColumns: 2,
+	}, AsyncInserterConfig{})
+
+	appendRow := func(columns []column.ColumnBasic) {
+		columns[0].(*column.Base[uint64]).Append(1)
+		columns[1].(*column.Base[uint64]).Append(1)
+	}
+	require.NoError(t, inserter.Append(context.Background(), "query", 0, appendRow))
+	// the buffered rows are discarded after a panic in the middle of the append
+	assert.Panics(t, func() {
+		_ = inserter.Append(context.Background(), "query", 0, func(columns []column.ColumnBasic) {
+			columns[0].(*column.Base[uint64]).Append(1)
+			panic("append")
+		})
+	})
+	err := inserter.Append(context.Background(), "query", 0, func(columns []column.ColumnBasic) {
+		columns[0].(*column.Base[uint64]).Append(1)
+	})
+	require.ErrorIs(t, err, ErrAsyncInserterNumRow)
+
+	// the lock of the buffer is released after the panic
+	done := make(chan error)
+	go func() {
+		done <- inserter.Append(context.Background(), "query", 0, appendRow)
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "append is blocked after the panic")
+	}
+	require.NoError(t, inserter.Close(context.Background()))
+	assert.Equal(t, int64(1), atomic.LoadInt64(&rows))
+}
+
+func TestAsyncInserterMaxInflight(t *testing.T) {
+	t.Parallel()
+
+	var inflight, maxInflight int64
+	release := make(chan struct{})
+	pool := &asyncTestPool{
+		insert: func(columns []column.ColumnBasic) {
+			n := atomic.AddInt64(&inflight, 1)
+			for {
+				m := atomic.LoadInt64(&maxInflight)
+				if n <= m || atomic.CompareAndSwapInt64(&maxInflight, m, n) {
+					break
+				}
+			}
+			<-release
+			atomic.AddInt64(&inflight, -1)
+		},
+	}
+	inserter := NewAsyncInserter(pool, AsyncInserterConfig{
+		MaxRows:     1,
+		MaxInflight: 2,
+	})
+
+	appendRow := func() error {
+		return inserter.Append(context.Background(), "query", 0, func(columns []column.ColumnBasic) {
+			columns[0].(*column.Base[uint64]).Append(1)
+		})
+	}
+	require.NoError(t, appendRow())
+	require.NoError(t, appendRow())
+
+	// the third batch waits for one of the inserted batches
+	done := make(chan error)
+	go func() {
+		done <- appendRow()
+	}()
+	select {
+	case <-done:
+		require.FailNow(t, "append is not blocked by MaxInflight")
+	case <-time.After(100 * time.Millisecond):
+	}
+	release <- struct{}{}
+	require.NoError(t, <-done)
+
+	close(release)
+	require.NoError(t, inserter.Close(context.Background()))
+	assert.Equal(t, int64(2), atomic.LoadInt64(&maxInflight))
+	// the columns of the new batches are built from the header of the first insert query
+	assert.Equal(t, int64(1), atomic.LoadInt64(&pool.headers))
+}
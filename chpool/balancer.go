@@ -0,0 +1,222 @@
+package chpool
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var defaultHostEjectThreshold = 3
+var defaultHostEjectTime = 30 * time.Second
+
+// LoadBalance is the strategy of choosing the host of the new connections of the pool
+// between the host of the config and `Fallbacks`.
+type LoadBalance int
+
+const (
+	// LoadBalanceInOrder connects to the first healthy host (like chconn.ConnectConfig).
+	LoadBalanceInOrder LoadBalance = iota
+	// LoadBalanceRoundRobin connects to the hosts in turn.
+	LoadBalanceRoundRobin
+	// LoadBalanceRandom connects to a random host.
+	LoadBalanceRandom
+	// LoadBalanceLeastConns connects to the host with the least number of open connections.
+	LoadBalanceLeastConns
+)
+
+var loadBalanceNames = map[string]LoadBalance{
+	"in_order":    LoadBalanceInOrder,
+	"round_robin": LoadBalanceRoundRobin,
+	"random":      LoadBalanceRandom,
+	"least_conns": LoadBalanceLeastConns,
+}
+
+func (l LoadBalance) String() string {
+	for name, v := range loadBalanceNames {
+		if v == l {
+			return name
+		}
+	}
+	return "LoadBalance(" + strconv.Itoa(int(l)) + ")"
+}
+
+func parseLoadBalance(s string) (LoadBalance, error) {
+	l, ok := loadBalanceNames[s]
+	if !ok {
+		//nolint:goerr113
+		return 0, fmt.Errorf("unknown load balance %q", s)
+	}
+	return l, nil
+}
+
+// HostStat is a snapshot of the statistics of a host of the pool.
+type HostStat struct {
+	// Address is the address of the host (host:port)
+	Address string
+	// Conns is the number of the open connections to the host.
+	Conns int32
+	// ConnectCount is the cumulative count of the connections opened to the host.
+	ConnectCount int64
+	// FailureCount is the cumulative count of the connect and query failures of the host.
+	FailureCount int64
+	// ConsecutiveFailures is the number of the failures since the last successful connect or query.
+	ConsecutiveFailures int
+	// EjectedUntil is the time that the host is ejected until it. it is zero if the host is not ejected.
+	EjectedUntil time.Time
+}
+
+// Healthy reports if the host is not ejected.
+func (s HostStat) Healthy() bool {
+	return s.EjectedUntil.IsZero() || time.Now().After(s.EjectedUntil)
+}
+
+// host is a server of the pool and its health.
+type host struct {
+	address             string
+	conns               int32
+	connectCount        int64
+	failureCount        int64
+	consecutiveFailures int
+	ejectedUntil        time.Time
+}
+
+// balancer chooses the hosts of the new connections and tracks the health of the hosts.
+type balancer struct {
+	strategy       LoadBalance
+	ejectThreshold int
+	ejectTime      time.Duration
+
+	mu    sync.Mutex
+	hosts map[string]*host
+	// order is the addresses in the order of the config, for Stat
+	order []string
+	next  int
+}
+
+func newBalancer(config *Config) *balancer {
+	b := &balancer{
+		strategy:       config.LoadBalance,
+		ejectThreshold: config.HostEjectThreshold,
+		ejectTime:      config.HostEjectTime,
+		hosts:          make(map[string]*host),
+	}
+	if b.ejectThreshold <= 0 {
+		b.ejectThreshold = defaultHostEjectThreshold
+	}
+	if b.ejectTime <= 0 {
+		b.ejectTime = defaultHostEjectTime
+	}
+	for _, hc := range hostsOf(config.ConnConfig) {
+		b.host(hc.address)
+	}
+	return b
+}
+
+// host returns the host of the address. it adds the host if it is not known.
+// the lock must be held or the balancer must not be shared yet.
+func (b *balancer) host(address string) *host {
+	h, ok := b.hosts[address]
+	if !ok {
+		h = &host{address: address}
+		b.hosts[address] = h
+		b.order = append(b.order, address)
+	}
+	return h
+}
+
+// pick returns the hosts in the order that they must be tried.
+// the ejected hosts and the host that the query is failed on it are tried last.
+func (b *balancer) pick(hosts []hostConfigs, failed string) []hostConfigs {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.strategy {
+	case LoadBalanceRoundRobin:
+		start := b.next % len(hosts)
+		b.next++
+		rotated := make([]hostConfigs, 0, len(hosts))
+		rotated = append(rotated, hosts[start:]...)
+		hosts = append(rotated, hosts[:start]...)
+	case LoadBalanceRandom:
+		rand.Shuffle(len(hosts), func(i, j int) {
+			hosts[i], hosts[j] = hosts[j], hosts[i]
+		})
+	case LoadBalanceLeastConns:
+		sort.SliceStable(hosts, func(i, j int) bool {
+			return b.host(hosts[i].address).conns < b.host(hosts[j].address).conns
+		})
+	}
+	now := time.Now()
+	healthy := func(address string) bool {
+		return address != failed && !now.Before(b.host(address).ejectedUntil)
+	}
+	sort.SliceStable(hosts, func(i, j int) bool {
+		return healthy(hosts[i].address) && !healthy(hosts[j].address)
+	})
+	return hosts
+}
+
+// connected records a new connection to the host.
+func (b *balancer) connected(address string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	h := b.host(address)
+	h.conns++
+	h.connectCount++
+	h.consecutiveFailures = 0
+	h.ejectedUntil = time.Time{}
+}
+
+// closed records that a connection to the host is closed.
+func (b *balancer) closed(address string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if h, ok := b.hosts[address]; ok {
+		h.conns--
+	}
+}
+
+// succeeded records a successful query on the host. it resets the consecutive failures of the host.
+func (b *balancer) succeeded(address string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if h, ok := b.hosts[address]; ok {
+		h.consecutiveFailures = 0
+		h.ejectedUntil = time.Time{}
+	}
+}
+
+// failed records a failure of the host and ejects it when it fails too many times.
+func (b *balancer) failed(address string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	h, ok := b.hosts[address]
+	if !ok {
+		return
+	}
+	h.failureCount++
+	h.consecutiveFailures++
+	if h.consecutiveFailures >= b.ejectThreshold {
+		h.ejectedUntil = time.Now().Add(b.ejectTime)
+	}
+}
+
+func (b *balancer) stat() []HostStat {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stats := make([]HostStat, len(b.order))
+	for i, address := range b.order {
+		h := b.hosts[address]
+		stats[i] = HostStat{
+			Address:             h.address,
+			Conns:               h.conns,
+			ConnectCount:        h.connectCount,
+			FailureCount:        h.failureCount,
+			ConsecutiveFailures: h.consecutiveFailures,
+			EjectedUntil:        h.ejectedUntil,
+		}
+	}
+	return stats
+}
@@ -0,0 +1,99 @@
+package chpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2"
+)
+
+func testBalancerConfig(l LoadBalance) *Config {
+	return &Config{
+		ConnConfig: &chconn.Config{
+			Host: "host1",
+			Port: 9000,
+			Fallbacks: []*chconn.FallbackConfig{
+				{Host: "host2", Port: 9000},
+				{Host: "host3", Port: 9000},
+				{Host: "host3", Port: 9000},
+			},
+		},
+		LoadBalance:        l,
+		HostEjectThreshold: 2,
+		HostEjectTime:      time.Minute,
+	}
+}
+
+func pickAddresses(b *balancer, config *Config, failed string) []string {
+	hosts := b.pick(hostsOf(config.ConnConfig), failed)
+	addresses := make([]string, len(hosts))
+	for i, h := range hosts {
+		addresses[i] = h.address
+	}
+	return addresses
+}
+
+func TestBalancer(t *testing.T) {
+	t.Parallel()
+
+	config := testBalancerConfig(LoadBalanceInOrder)
+	b := newBalancer(config)
+	assert.Equal(t, []string{"host1:9000", "host2:9000", "host3:9000"}, pickAddresses(b, config, ""))
+	// the duplicated addresses are grouped
+	assert.Len(t, b.pick(hostsOf(config.ConnConfig), "")[2].configs, 2)
+	// the failed host of the retry is tried last
+	assert.Equal(t, []string{"host2:9000", "host3:9000", "host1:9000"}, pickAddresses(b, config, "host1:9000"))
+
+	// the host is ejected after HostEjectThreshold failures
+	b.failed("host2:9000")
+	assert.Equal(t, []string{"host1:9000", "host2:9000", "host3:9000"}, pickAddresses(b, config, ""))
+	b.failed("host2:9000")
+	assert.Equal(t, []string{"host1:9000", "host3:9000", "host2:9000"}, pickAddresses(b, config, ""))
+
+	stat := b.stat()
+	require.Len(t, stat, 3)
+	assert.True(t, stat[0].Healthy())
+	assert.False(t, stat[1].Healthy())
+	assert.Equal(t, int64(2), stat[1].FailureCount)
+
+	// a successful connect restores the host
+	b.connected("host2:9000")
+	assert.Equal(t, []string{"host1:9000", "host2:9000", "host3:9000"}, pickAddresses(b, config, ""))
+	stat = b.stat()
+	assert.True(t, stat[1].Healthy())
+	assert.Equal(t, int32(1), stat[1].Conns)
+	b.closed("host2:9000")
+	assert.Equal(t, int32(0), b.stat()[1].Conns)
+}
+
+func TestBalancerStrategies(t *testing.T) {
+	t.Parallel()
+
+	config := testBalancerConfig(LoadBalanceRoundRobin)
+	b := newBalancer(config)
+	assert.Equal(t, []string{"host1:9000", "host2:9000", "host3:9000"}, pickAddresses(b, config, ""))
+	assert.Equal(t, []string{"host2:9000", "host3:9000", "host1:9000"}, pickAddresses(b, config, ""))
+	assert.Equal(t, []string{"host3:9000", "host1:9000", "host2:9000"}, pickAddresses(b, config, ""))
+
+	config = testBalancerConfig(LoadBalanceLeastConns)
+	b = newBalancer(config)
+	b.connected("host1:9000")
+	b.connected("host2:9000")
+	b.connected("host1:9000")
+	assert.Equal(t, []string{"host3:9000", "host2:9000", "host1:9000"}, pickAddresses(b, config, ""))
+
+	config = testBalancerConfig(LoadBalanceRandom)
+	b = newBalancer(config)
+	assert.ElementsMatch(t, []string{"host1:9000", "host2:9000", "host3:9000"}, pickAddresses(b, config, ""))
+
+	for name, l := range loadBalanceNames {
+		parsed, err := parseLoadBalance(name)
+		require.NoError(t, err)
+		assert.Equal(t, l, parsed)
+		assert.Equal(t, name, l.String())
+	}
+	_, err := parseLoadBalance("unknown")
+	assert.Error(t, err)
+}
@@ -93,6 +93,14 @@ func (c *conn) Release() {
 	}()
 }
 
+// connHost returns the address of the server of an acquired connection.
+func connHost(c Conn) string {
+	if c, ok := c.(*conn); ok && c.res != nil {
+		return c.res.Value().host
+	}
+	return ""
+}
+
 // Hijack assumes ownership of the connection from the pool. Caller is responsible for closing the connection. Hijack
 // will panic if called on an already released or hijacked connection.
 func (c *conn) Hijack() chconn.Conn {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"net"
 	"runtime"
 	"strconv"
 	"sync"
@@ -25,7 +26,9 @@ var defaultMaxConnIdleTime = time.Minute * 30
 var defaultHealthCheckPeriod = time.Minute
 
 type connResource struct {
-	conn  chconn.Conn
+	conn chconn.Conn
+	// host is the address (host:port) of the server that the connection is connected to
+	host  string
 	conns []conn
 }
 
@@ -69,12 +72,14 @@ type Pool interface {
 	// Insert executes a insert query and commit all columns data.
 	//
 	// If the query is successful, the columns buffer will be reset.
+	// If the query fails, the columns keep their data. so the insert can be called again with the same columns.
 	//
 	// NOTE: only use for insert query
 	Insert(ctx context.Context, query string, columns ...column.ColumnBasic) error
 	// InsertWithOption executes a insert query with the query options and commit all columns data.
 	//
 	// If the query is successful, the columns buffer will be reset.
+	// If the query fails, the columns keep their data. so the insert can be called again with the same columns.
 	//
 	// NOTE: only use for insert query
 	InsertWithOption(ctx context.Context, query string, queryOptions *chconn.QueryOptions, columns ...column.ColumnBasic) error
@@ -133,6 +138,8 @@ type pool struct {
 
 	healthCheckChan chan struct{}
 
+	balancer *balancer
+
 	newConnsCount        int64
 	lifetimeDestroyCount int64
 	idleDestroyCount     int64
@@ -186,6 +193,22 @@ type Config struct {
 	// CreateIdleTimeout is  the timeout for create idle connection
 	CreateIdleTimeout time.Duration
 
+	// RetryPolicy is the policy of retrying Exec, Select, Insert and Ping on another host after network failures
+	// or retryable server errors. nil disables retry.
+	RetryPolicy *RetryPolicy
+
+	// LoadBalance is the strategy of choosing the host of the new connections between the host of ConnConfig and
+	// ConnConfig.Fallbacks. The default is LoadBalanceInOrder.
+	// NOTE: The idle connections are reused regardless of their host.
+	LoadBalance LoadBalance
+
+	// HostEjectThreshold is the number of the consecutive connect or query failures of a host after which the host is
+	// ejected. The ejected hosts are only used when all the other hosts are failed. Default 3.
+	HostEjectThreshold int
+
+	// HostEjectTime is the duration that a failing host is ejected. Default 30s.
+	HostEjectTime time.Duration
+
 	createdByParseConfig bool // Used to enforce created by ParseConfig rule.
 }
 
@@ -196,6 +219,10 @@ func (c *Config) Copy() *Config {
 	newConfig := new(Config)
 	*newConfig = *c
 	newConfig.ConnConfig = c.ConnConfig.Copy()
+	if c.RetryPolicy != nil {
+		retryPolicy := *c.RetryPolicy
+		newConfig.RetryPolicy = &retryPolicy
+	}
 	return newConfig
 }
 
@@ -234,6 +261,7 @@ func NewWithConfig(config *Config) (Pool, error) {
 		healthCheckPeriod:     config.HealthCheckPeriod,
 		healthCheckChan:       make(chan struct{}, 1),
 		closeChan:             make(chan struct{}),
+		balancer:              newBalancer(config),
 	}
 
 	var err error
@@ -253,7 +281,7 @@ func NewWithConfig(config *Config) (Pool, error) {
 					}
 				}
 
-				c, err := chconn.ConnectConfig(ctx, connConfig)
+				c, host, err := p.connect(ctx, connConfig)
 				if err != nil {
 					return nil, err
 				}
@@ -262,12 +290,14 @@ func NewWithConfig(config *Config) (Pool, error) {
 					err := p.afterConnect(ctx, c)
 					if err != nil {
 						c.Close()
+						p.balancer.closed(host)
 						return nil, err
 					}
 				}
 
 				cr := &connResource{
 					conn:  c,
+					host:  host,
 					conns: make([]conn, 64),
 				}
 
@@ -275,6 +305,7 @@ func NewWithConfig(config *Config) (Pool, error) {
 			},
 			Destructor: func(value *connResource) {
 				value.conn.Close()
+				p.balancer.closed(value.host)
 			},
 			MaxSize: config.MaxConns,
 		},
@@ -292,6 +323,66 @@ func NewWithConfig(config *Config) (Pool, error) {
 	return p, nil
 }
 
+// hostConfigs is the address of a host and its configs. the fallbacks with the same address
+// (e.g. with and without TLS) are grouped in a host.
+type hostConfigs struct {
+	address string
+	configs []*chconn.FallbackConfig
+}
+
+// hostsOf returns the hosts of the config in the order of the config.
+func hostsOf(connConfig *chconn.Config) []hostConfigs {
+	configs := make([]*chconn.FallbackConfig, 0, len(connConfig.Fallbacks)+1)
+	configs = append(configs, &chconn.FallbackConfig{
+		Host:      connConfig.Host,
+		Port:      connConfig.Port,
+		TLSConfig: connConfig.TLSConfig,
+	})
+	configs = append(configs, connConfig.Fallbacks...)
+
+	hosts := make([]hostConfigs, 0, len(configs))
+	index := make(map[string]int, len(configs))
+	for _, fc := range configs {
+		address := net.JoinHostPort(fc.Host, strconv.Itoa(int(fc.Port)))
+		i, ok := index[address]
+		if !ok {
+			i = len(hosts)
+			index[address] = i
+			hosts = append(hosts, hostConfigs{address: address})
+		}
+		hosts[i].configs = append(hosts[i].configs, fc)
+	}
+	return hosts
+}
+
+// connect connects to a host of the config by the load balance strategy.
+// the host that the query of the retry is failed on it (see RetryPolicy) is tried last.
+func (p *pool) connect(ctx context.Context, connConfig *chconn.Config) (chconn.Conn, string, error) {
+	var err error
+	for _, hc := range p.balancer.pick(hostsOf(connConfig), failedHost(ctx)) {
+		for _, fc := range hc.configs {
+			hostConfig := connConfig.Copy()
+			hostConfig.Host = fc.Host
+			hostConfig.Port = fc.Port
+			hostConfig.TLSConfig = fc.TLSConfig
+			hostConfig.Fallbacks = nil
+			var c chconn.Conn
+			c, err = chconn.ConnectConfig(ctx, hostConfig)
+			if err == nil {
+				p.balancer.connected(hc.address)
+				return c, hc.address, nil
+			}
+			// the server errors (e.g. authentication) terminate the chain of attempts like chconn.ConnectConfig
+			var chErr *chconn.ChError
+			if errors.As(err, &chErr) || ctx.Err() != nil {
+				return nil, "", err
+			}
+		}
+		p.balancer.failed(hc.address)
+	}
+	return nil, "", err
+}
+
 // ParseConfig builds a Config from connString. It parses connString with the same behavior as chconn.ParseConfig with the
 // addition of the following variables:
 //
@@ -302,6 +393,10 @@ func NewWithConfig(config *Config) (Pool, error) {
 // pool_health_check_period: duration string
 // pool_max_conn_lifetime_jitter: duration string
 // pool_create_idle_timeout: duration string
+// pool_max_retries: integer 0 or greater (enables RetryPolicy with the default backoff and codes)
+// pool_load_balance: in_order, round_robin, random or least_conns
+// pool_host_eject_threshold: integer greater than 0
+// pool_host_eject_time: duration string
 //
 // See Config for definitions of these arguments.
 //
@@ -403,6 +498,48 @@ func ParseConfig(connString string) (*Config, error) {
 		config.CreateIdleTimeout = defaultCreateIdleTimeout
 	}
 
+	if s, ok := config.ConnConfig.RuntimeParams["pool_max_retries"]; ok {
+		delete(config.ConnConfig.RuntimeParams, "pool_max_retries")
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse pool_max_retries: %w", err)
+		}
+		if n > 0 {
+			config.RetryPolicy = &RetryPolicy{MaxRetries: int(n)}
+		}
+	}
+
+	if s, ok := config.ConnConfig.RuntimeParams["pool_load_balance"]; ok {
+		delete(config.ConnConfig.RuntimeParams, "pool_load_balance")
+		l, err := parseLoadBalance(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pool_load_balance: %w", err)
+		}
+		config.LoadBalance = l
+	}
+
+	if s, ok := config.ConnConfig.RuntimeParams["pool_host_eject_threshold"]; ok {
+		delete(config.ConnConfig.RuntimeParams, "pool_host_eject_threshold")
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse pool_host_eject_threshold: %w", err)
+		}
+		config.HostEjectThreshold = int(n)
+	} else {
+		config.HostEjectThreshold = defaultHostEjectThreshold
+	}
+
+	if s, ok := config.ConnConfig.RuntimeParams["pool_host_eject_time"]; ok {
+		delete(config.ConnConfig.RuntimeParams, "pool_host_eject_time")
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pool_host_eject_time: %w", err)
+		}
+		config.HostEjectTime = d
+	} else {
+		config.HostEjectTime = defaultHostEjectTime
+	}
+
 	return config, nil
 }
 
@@ -545,6 +682,11 @@ func (p *pool) createIdleResources(targetResources int) error {
 
 // Acquire returns a connection (Conn) from the Pool
 func (p *pool) Acquire(ctx context.Context) (Conn, error) {
+	start := time.Now()
+	failed := failedHost(ctx)
+	if len(p.config.ConnConfig.Fallbacks) == 0 {
+		failed = ""
+	}
 	for {
 		res, err := p.p.Acquire(ctx)
 		if err != nil {
@@ -553,6 +695,13 @@ func (p *pool) Acquire(ctx context.Context) (Conn, error) {
 
 		cr := res.Value()
 
+		// the retry of a failed query must not use the connections to the failed host.
+		// a new connection is only connected to it when the other hosts are not reachable.
+		if failed != "" && cr.host == failed && res.CreationTime().Before(start) {
+			res.Destroy()
+			continue
+		}
+
 		if res.IdleDuration() > time.Second {
 			err := cr.conn.Ping(ctx)
 			if err != nil {
@@ -618,6 +767,7 @@ func (p *pool) Stat() *Stat {
 		newConnsCount:        atomic.LoadInt64(&p.newConnsCount),
 		lifetimeDestroyCount: atomic.LoadInt64(&p.lifetimeDestroyCount),
 		idleDestroyCount:     atomic.LoadInt64(&p.idleDestroyCount),
+		hosts:                p.balancer.stat(),
 	}
 }
 
@@ -630,18 +780,21 @@ func (p *pool) ExecWithOption(
 	query string,
 	queryOptions *chconn.QueryOptions,
 ) error {
-	for {
-		c, err := p.Acquire(ctx)
-		if err != nil {
-			return err
-		}
-		err = c.ExecWithOption(ctx, query, queryOptions)
-		c.Release()
-		if errors.Is(err, syscall.EPIPE) {
-			continue
+	return p.retry(ctx, queryOptions != nil && queryOptions.Idempotent, func(ctx context.Context) (string, error) {
+		for {
+			c, err := p.Acquire(ctx)
+			if err != nil {
+				return "", err
+			}
+			err = c.ExecWithOption(ctx, query, queryOptions)
+			host := connHost(c)
+			c.Release()
+			if errors.Is(err, syscall.EPIPE) {
+				continue
+			}
+			return host, err
 		}
-		return err
-	}
+	})
 }
 
 func (p *pool) Select(ctx context.Context, query string, columns ...column.ColumnBasic) (chconn.SelectStmt, error) {
@@ -654,22 +807,30 @@ func (p *pool) SelectWithOption(
 	queryOptions *chconn.QueryOptions,
 	columns ...column.ColumnBasic,
 ) (chconn.SelectStmt, error) {
-	for {
-		c, err := p.Acquire(ctx)
-		if err != nil {
-			return nil, err
-		}
+	var s chconn.SelectStmt
+	err := p.retry(ctx, true, func(ctx context.Context) (string, error) {
+		for {
+			c, err := p.Acquire(ctx)
+			if err != nil {
+				return "", err
+			}
 
-		s, err := c.SelectWithOption(ctx, query, queryOptions, columns...)
-		if err != nil {
-			c.Release()
-			if errors.Is(err, syscall.EPIPE) {
-				continue
+			s, err = c.SelectWithOption(ctx, query, queryOptions, columns...)
+			if err != nil {
+				host := connHost(c)
+				c.Release()
+				if errors.Is(err, syscall.EPIPE) {
+					continue
+				}
+				return host, err
 			}
-			return nil, err
+			return "", nil
 		}
-		return s, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return s, nil
 }
 
 func (p *pool) Insert(ctx context.Context, query string, columns ...column.ColumnBasic) error {
@@ -677,19 +838,31 @@ func (p *pool) Insert(ctx context.Context, query string, columns ...column.Colum
 }
 
 func (p *pool) InsertWithOption(ctx context.Context, query string, queryOptions *chconn.QueryOptions, columns ...column.ColumnBasic) error {
-	for {
-		c, err := p.Acquire(ctx)
-		if err != nil {
-			return err
-		}
+	idempotent := queryOptions != nil && queryOptions.Idempotent
+	numRow := -1
+	if len(columns) != 0 {
+		numRow = columns[0].NumRow()
+	}
+	return p.retry(ctx, idempotent, func(ctx context.Context) (string, error) {
+		for {
+			c, err := p.Acquire(ctx)
+			if err != nil {
+				return "", err
+			}
 
-		err = c.InsertWithOption(ctx, query, queryOptions, columns...)
-		c.Release()
-		if err != nil && errors.Is(err, syscall.EPIPE) {
-			continue
+			err = c.InsertWithOption(ctx, query, queryOptions, columns...)
+			host := connHost(c)
+			c.Release()
+			if err != nil && errors.Is(err, syscall.EPIPE) {
+				continue
+			}
+			// the columns are kept until the insert is flushed. the insert can not be retried if they are consumed
+			if err != nil && numRow != -1 && columns[0].NumRow() != numRow {
+				return host, &permanentError{err: err}
+			}
+			return host, err
 		}
-		return err
-	}
+	})
 }
 
 func (p *pool) InsertStream(ctx context.Context, query string) (chconn.InsertStmt, error) {
@@ -697,37 +870,48 @@ func (p *pool) InsertStream(ctx context.Context, query string) (chconn.InsertStm
 }
 
 func (p *pool) InsertStreamWithOption(ctx context.Context, query string, queryOptions *chconn.QueryOptions) (chconn.InsertStmt, error) {
-	for {
-		c, err := p.Acquire(ctx)
-		if err != nil {
-			return nil, err
-		}
+	var s chconn.InsertStmt
+	err := p.retry(ctx, true, func(ctx context.Context) (string, error) {
+		for {
+			c, err := p.Acquire(ctx)
+			if err != nil {
+				return "", err
+			}
 
-		s, err := c.InsertStreamWithOption(ctx, query, queryOptions)
-		if err != nil {
-			c.Release()
-			if errors.Is(err, syscall.EPIPE) {
-				continue
+			s, err = c.InsertStreamWithOption(ctx, query, queryOptions)
+			if err != nil {
+				host := connHost(c)
+				c.Release()
+				if errors.Is(err, syscall.EPIPE) {
+					continue
+				}
+				return host, err
 			}
-			return nil, err
+			return "", nil
 		}
-		return s, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return s, nil
 }
 
 // Ping acquires a connection from the Pool and send ping
 // If returns without error, the database Ping is considered successful, otherwise, the error is returned.
 func (p *pool) Ping(ctx context.Context) error {
-	for {
-		c, err := p.Acquire(ctx)
-		if err != nil {
-			return err
-		}
-		err = c.Ping(ctx)
-		c.Release()
-		if errors.Is(err, syscall.EPIPE) {
-			continue
+	return p.retry(ctx, true, func(ctx context.Context) (string, error) {
+		for {
+			c, err := p.Acquire(ctx)
+			if err != nil {
+				return "", err
+			}
+			err = c.Ping(ctx)
+			host := connHost(c)
+			c.Release()
+			if errors.Is(err, syscall.EPIPE) {
+				continue
+			}
+			return host, err
 		}
-		return err
-	}
+	})
 }
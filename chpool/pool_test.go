@@ -43,19 +43,22 @@ func TestParseConfigExtractsPoolArguments(t *testing.T) {
 								pool_min_conns=1
 								pool_max_conn_lifetime=30s
 								pool_max_conn_idle_time=31s
-								pool_health_check_period=32s`)
+								pool_health_check_period=32s
+								pool_max_retries=2`)
 	assert.NoError(t, err)
 	assert.EqualValues(t, 42, config.MaxConns)
 	assert.EqualValues(t, 42, config.MaxConns)
 	assert.EqualValues(t, time.Second*30, config.MaxConnLifetime)
 	assert.EqualValues(t, time.Second*31, config.MaxConnIdleTime)
 	assert.EqualValues(t, time.Second*32, config.HealthCheckPeriod)
+	assert.EqualValues(t, 2, config.RetryPolicy.MaxRetries)
 
 	assert.NotContains(t, config.ConnConfig.RuntimeParams, "pool_max_conns")
 	assert.NotContains(t, config.ConnConfig.RuntimeParams, "pool_min_conns")
 	assert.NotContains(t, config.ConnConfig.RuntimeParams, "pool_max_conn_lifetime")
 	assert.NotContains(t, config.ConnConfig.RuntimeParams, "pool_max_conn_idle_time")
 	assert.NotContains(t, config.ConnConfig.RuntimeParams, "pool_health_check_period")
+	assert.NotContains(t, config.ConnConfig.RuntimeParams, "pool_max_retries")
 }
 
 func TestConnectConfigRequiresConnConfigFromParseConfig(t *testing.T) {
@@ -816,6 +819,10 @@ func TestParseConfigError(t *testing.T) {
 			name:       "invalid pool_create_idle_timeout",
 			connString: "pool_create_idle_timeout=invalid",
 			err:        "invalid pool_create_idle_timeout: time: invalid duration \"invalid\"",
+		}, {
+			name:       "invalid pool_max_retries",
+			connString: "pool_max_retries=invalid",
+			err:        "cannot parse pool_max_retries: strconv.ParseInt: parsing \"invalid\": invalid syntax",
 		},
 	}
 
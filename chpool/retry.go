@@ -0,0 +1,190 @@
+package chpool
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/vahid-sohrabloo/chconn/v2"
+)
+
+var defaultRetryInitialBackoff = 100 * time.Millisecond
+var defaultRetryMaxBackoff = 5 * time.Second
+
+// DefaultRetryableCodes is the ClickHouse error codes that are retried when RetryPolicy.RetryableCodes is nil.
+// the server rejects the query with these errors before running it, so they are safe to retry for all the queries.
+var DefaultRetryableCodes = []chconn.ChErrorType{
+	chconn.ChErrorTooManySimultaneousQueries,
+}
+
+// DefaultIdempotentRetryableCodes is the ClickHouse error codes that are retried for the idempotent queries
+// when RetryPolicy.IdempotentRetryableCodes is nil.
+// the server can send these errors after a part of the query is run (e.g. a distributed query that loses
+// the connection to a shard), so they are not retried for the other queries.
+var DefaultIdempotentRetryableCodes = []chconn.ChErrorType{
+	chconn.ChErrorSocketTimeout,
+	chconn.ChErrorNetworkError,
+	chconn.ChErrorAllConnectionTriesFailed,
+}
+
+// RetryPolicy is the configuration of retrying the queries of the pool on another host.
+//
+// A query is retried if the server rejects it with one of the RetryableCodes. network errors and
+// IdempotentRetryableCodes are only retried for Select, Ping and the queries that are marked by
+// `chconn.QueryOptions.Idempotent`, because the server may have run the query before the error.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries after the first attempt. zero disables retry.
+	MaxRetries int
+	// InitialBackoff is the wait time before the first retry. it doubles on each retry. Default 100ms.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum wait time between the retries. Default 5s.
+	MaxBackoff time.Duration
+	// RetryableCodes is the ClickHouse error codes that are retried. nil uses DefaultRetryableCodes.
+	RetryableCodes []chconn.ChErrorType
+	// IdempotentRetryableCodes is the ClickHouse error codes that are only retried for the idempotent queries.
+	// nil uses DefaultIdempotentRetryableCodes.
+	IdempotentRetryableCodes []chconn.ChErrorType
+}
+
+// backoff returns the wait time before the given retry (starts from zero) with jitter.
+func (r *RetryPolicy) backoff(retry int) time.Duration {
+	initial := r.InitialBackoff
+	if initial <= 0 {
+		initial = defaultRetryInitialBackoff
+	}
+	max := r.MaxBackoff
+	if max <= 0 {
+		max = defaultRetryMaxBackoff
+	}
+	d := initial
+	for i := 0; i < retry && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	// full jitter in the upper half to avoid all the clients retry at the same time
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryable reports if the query can be retried after err.
+func (r *RetryPolicy) retryable(err error, idempotent bool) bool {
+	var chErr *chconn.ChError
+	if errors.As(err, &chErr) {
+		codes := r.RetryableCodes
+		if codes == nil {
+			codes = DefaultRetryableCodes
+		}
+		if hasChErrorCode(codes, chErr.Code) {
+			return true
+		}
+		if !idempotent {
+			return false
+		}
+		codes = r.IdempotentRetryableCodes
+		if codes == nil {
+			codes = DefaultIdempotentRetryableCodes
+		}
+		return hasChErrorCode(codes, chErr.Code)
+	}
+	if !idempotent {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
+
+func hasChErrorCode(codes []chconn.ChErrorType, code chconn.ChErrorType) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// isHostFailure reports if err is a failure of the host (and not the query) that counts for ejecting the host.
+func isHostFailure(err error) bool {
+	var chErr *chconn.ChError
+	if errors.As(err, &chErr) {
+		return chErr.Code == chconn.ChErrorTooManySimultaneousQueries
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE)
+}
+
+type failedHostKey struct{}
+
+// failedHost returns the host that the query is failed on it from the context of the retry.
+func failedHost(ctx context.Context) string {
+	host, _ := ctx.Value(failedHostKey{}).(string)
+	return host
+}
+
+// permanentError stops the retry of the query. e.g. when the data of the insert is consumed.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// retry calls f until it succeeds or the error is not retryable. f is called with the context that
+// asks the pool to not use the host of the previous attempt.
+// f returns the host that the query is sent to it. it is empty if the query is not sent (e.g. acquire error),
+// so the attempt can be retried even if the query is not idempotent.
+func (p *pool) retry(
+	ctx context.Context,
+	idempotent bool,
+	f func(ctx context.Context) (host string, err error),
+) error {
+	retryCtx := ctx
+	for retry := 0; ; retry++ {
+		host, err := f(retryCtx)
+		if err == nil {
+			if host != "" {
+				p.balancer.succeeded(host)
+			}
+			return nil
+		}
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+		policy := p.config.RetryPolicy
+		if host != "" && isHostFailure(err) {
+			p.balancer.failed(host)
+		}
+		if policy == nil || retry >= policy.MaxRetries || ctx.Err() != nil ||
+			!policy.retryable(err, idempotent || host == "") {
+			return err
+		}
+		timer := time.NewTimer(policy.backoff(retry))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		if host != "" {
+			retryCtx = context.WithValue(ctx, failedHostKey{}, host)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package chpool
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := &RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     4 * time.Millisecond,
+	}
+	for retry := 0; retry < 10; retry++ {
+		d := policy.backoff(retry)
+		assert.LessOrEqual(t, d, 4*time.Millisecond)
+		assert.GreaterOrEqual(t, d, time.Millisecond/2)
+	}
+
+	tooMany := &chconn.ChError{Code: chconn.ChErrorTooManySimultaneousQueries}
+	assert.True(t, policy.retryable(tooMany, false))
+	assert.False(t, policy.retryable(&chconn.ChError{Code: chconn.ChErrorSyntaxError}, true))
+	socketTimeout := &chconn.ChError{Code: chconn.ChErrorSocketTimeout}
+	assert.False(t, policy.retryable(socketTimeout, false))
+	assert.True(t, policy.retryable(socketTimeout, true))
+	assert.False(t, policy.retryable(io.EOF, false))
+	assert.True(t, policy.retryable(io.EOF, true))
+
+	p := &pool{config: &Config{RetryPolicy: policy}, balancer: &balancer{hosts: map[string]*host{}}}
+	var hosts []string
+	err := p.retry(context.Background(), false, func(ctx context.Context) (string, error) {
+		hosts = append(hosts, failedHost(ctx))
+		if len(hosts) < 3 {
+			return "host" + string(rune('0'+len(hosts))), tooMany
+		}
+		return "host3", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "host1", "host2"}, hosts)
+
+	// the network errors are not retried for the non-idempotent queries
+	calls := 0
+	err = p.retry(context.Background(), false, func(ctx context.Context) (string, error) {
+		calls++
+		return "host", io.EOF
+	})
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	err = p.retry(context.Background(), true, func(ctx context.Context) (string, error) {
+		calls++
+		return "host", io.EOF
+	})
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, 4, calls)
+
+	calls = 0
+	errPermanent := errors.New("permanent")
+	err = p.retry(context.Background(), true, func(ctx context.Context) (string, error) {
+		calls++
+		return "host", &permanentError{err: errPermanent}
+	})
+	assert.Equal(t, errPermanent, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryHostSuccess(t *testing.T) {
+	t.Parallel()
+
+	config := testBalancerConfig(LoadBalanceInOrder)
+	config.HostEjectThreshold = 3
+	p := &pool{config: config, balancer: newBalancer(config)}
+	query := func(err error) {
+		_ = p.retry(context.Background(), false, func(ctx context.Context) (string, error) {
+			return "host1:9000", err
+		})
+	}
+
+	// a successful query resets the consecutive failures of the host
+	query(io.EOF)
+	query(nil)
+	assert.Equal(t, 0, p.balancer.stat()[0].ConsecutiveFailures)
+	query(io.EOF)
+	query(io.EOF)
+	stat := p.balancer.stat()[0]
+	assert.Equal(t, 2, stat.ConsecutiveFailures)
+	assert.Equal(t, int64(3), stat.FailureCount)
+	assert.True(t, stat.Healthy())
+
+	query(io.EOF)
+	assert.False(t, p.balancer.stat()[0].Healthy())
+}
@@ -12,6 +12,7 @@ type Stat struct {
 	newConnsCount        int64
 	lifetimeDestroyCount int64
 	idleDestroyCount     int64
+	hosts                []HostStat
 }
 
 // AcquireCount returns the cumulative count of successful acquires from the pool.
@@ -82,3 +83,8 @@ func (s *Stat) MaxLifetimeDestroyCount() int64 {
 func (s *Stat) MaxIdleDestroyCount() int64 {
 	return s.idleDestroyCount
 }
+
+// Hosts returns the statistics of the hosts of the pool in the order of the config.
+func (s *Stat) Hosts() []HostStat {
+	return s.hosts
+}
@@ -29,7 +29,7 @@ type ClientInfo struct {
 
 // Write Only values that are not calculated automatically or passed separately are serialized.
 // Revisions are passed to use format that server will understand or client was used.
-func (c *ClientInfo) write(ch *conn) {
+func (c *ClientInfo) write(ch *conn, trace *TraceContext) {
 	// InitialQuery
 	ch.writer.Uint8(1)
 
@@ -64,7 +64,7 @@ func (c *ClientInfo) write(ch *conn) {
 	}
 
 	if ch.serverInfo.Revision >= helper.DbmsMinRevisionWithOpenTelemetry {
-		ch.writer.Uint8(0)
+		trace.write(ch)
 	}
 
 	if ch.serverInfo.Revision >= helper.DbmsMinProtocolVersionWithParallelReplicas {
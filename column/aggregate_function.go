@@ -0,0 +1,450 @@
+package column
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/vahid-sohrabloo/chconn/v2/internal/helper"
+	"github.com/vahid-sohrabloo/chconn/v2/internal/readerwriter"
+)
+
+// AggregateFunction is a column of AggregateFunction(name, T1, ..., Tn) ClickHouse data type
+//
+// The values are the opaque serialized states of the aggregate function.
+// The states do not have any length prefix. So for reading, the layout of the state must be known.
+// Reading is supported for these functions:
+//
+//	count
+//	sum, sumWithOverflow (numbers and decimals)
+//	min, max, any, anyLast (fixed size types and String)
+//	uniq
+//	uniqExact
+//	groupBitmap
+//
+// Inserting is supported for all the functions. The states are written as they are (e.g. the states that are read
+// from another cluster).
+type AggregateFunction struct {
+	column
+	numRow     int
+	version    string
+	function   string
+	argTypes   [][]byte
+	readState  func() error
+	writerData []byte
+	vals       []byte
+	pos        []stringPos
+}
+
+// aggregateFunctionReadChunk is the maximum size of the buffer that is grown for reading a state at once
+const aggregateFunctionReadChunk = 64 * 1024
+
+// NewAggregateFunction create a new column of AggregateFunction ClickHouse data type
+func NewAggregateFunction() *AggregateFunction {
+	return &AggregateFunction{}
+}
+
+// Function return the name of the aggregate function (with the parameters)
+//
+// It is available after validate (e.g. after the first block of select).
+func (c *AggregateFunction) Function() string {
+	return c.function
+}
+
+// ArgTypes return the ClickHouse types of the arguments of the aggregate function
+//
+// It is available after validate (e.g. after the first block of select).
+func (c *AggregateFunction) ArgTypes() [][]byte {
+	return c.argTypes
+}
+
+// Data get all the states in current block as a slice.
+//
+// NOTE: the return slice only valid in current block, if you want to use it after, you should copy it. or use Read
+func (c *AggregateFunction) Data() [][]byte {
+	val := make([][]byte, len(c.pos))
+	for i, p := range c.pos {
+		val[i] = c.vals[p.start:p.end]
+	}
+	return val
+}
+
+// Read reads all the states in current block and append a copy of them to the input.
+func (c *AggregateFunction) Read(value [][]byte) [][]byte {
+	for _, p := range c.pos {
+		value = append(value, append([]byte(nil), c.vals[p.start:p.end]...))
+	}
+	return value
+}
+
+// Row return the state of given row.
+//
+// NOTE: Row number start from zero. data is valid only in the current block.
+func (c *AggregateFunction) Row(row int) []byte {
+	p := c.pos[row]
+	return c.vals[p.start:p.end]
+}
+
+// RowAny return the state of given row as any.
+//
+// NOTE: Row number start from zero
+func (c *AggregateFunction) RowAny(row int) any {
+	return c.Row(row)
+}
+
+// Append the serialized states for insert
+func (c *AggregateFunction) Append(v ...[]byte) {
+	for _, v := range v {
+		c.writerData = append(c.writerData, v...)
+	}
+	c.numRow += len(v)
+}
+
+// NumRow return number of row for this block
+func (c *AggregateFunction) NumRow() int {
+	return c.numRow
+}
+
+// Array return a Array type for this column
+func (c *AggregateFunction) Array() *Array[[]byte] {
+	return NewArray[[]byte](c)
+}
+
+// Reset all statuses and buffered data
+//
+// After each reading, the reading data does not need to be reset. It will be automatically reset.
+//
+// When inserting, buffers are reset only after the operation is successful.
+// If an error occurs, you can safely call insert again.
+func (c *AggregateFunction) Reset() {
+	c.numRow = 0
+	c.vals = c.vals[:0]
+	c.pos = c.pos[:0]
+	c.writerData = c.writerData[:0]
+}
+
+// SetWriteBufferSize set write buffer (number of bytes)
+// this buffer only used for writing.
+// By setting this buffer, you will avoid allocating the memory several times.
+func (c *AggregateFunction) SetWriteBufferSize(b int) {
+	if cap(c.writerData) < b {
+		c.writerData = make([]byte, 0, b)
+	}
+}
+
+// ReadRaw read raw data from the reader. it runs automatically
+func (c *AggregateFunction) ReadRaw(num int, r *readerwriter.Reader) error {
+	c.Reset()
+	c.r = r
+	c.numRow = num
+	if num == 0 {
+		return nil
+	}
+	if c.readState == nil {
+		return ErrUnsupportedAggregateFunction{
+			column: c,
+		}
+	}
+	var p stringPos
+	for i := 0; i < num; i++ {
+		p.start = len(c.vals)
+		if err := c.readState(); err != nil {
+			return fmt.Errorf("aggregate function: read state: %w", err)
+		}
+		p.end = len(c.vals)
+		c.pos = append(c.pos, p)
+	}
+	return nil
+}
+
+// HeaderReader reads header data from reader
+// it uses internally
+func (c *AggregateFunction) HeaderReader(r *readerwriter.Reader, readColumn bool, revision uint64) error {
+	c.r = r
+	return c.readColumn(readColumn, revision)
+}
+
+func (c *AggregateFunction) Validate() error {
+	if !helper.IsAggregateFunction(c.chType) {
+		return &ErrInvalidType{
+			column: c,
+		}
+	}
+	types := c.chType[helper.LenAggregateFunctionStr : len(c.chType)-1]
+	if len(types) == 0 {
+		//nolint:goerr113
+		return fmt.Errorf("aggregate function without function name: %s", c.chType)
+	}
+	params, err := helper.TypesInParentheses(types)
+	if err != nil {
+		return fmt.Errorf("aggregate function invalid types: %w", err)
+	}
+	// the first parameter can be the version of the function state
+	c.version = ""
+	if _, err := strconv.Atoi(string(params[0].ChType)); err == nil && len(params) > 1 {
+		c.version = string(params[0].ChType)
+		params = params[1:]
+	}
+	c.function = string(params[0].ChType)
+	c.argTypes = c.argTypes[:0]
+	for _, p := range params[1:] {
+		c.argTypes = append(c.argTypes, p.ChType)
+	}
+	c.readState = c.stateReader()
+	return nil
+}
+
+// stateReader return the function to read the state of one row. it returns nil if the layout is unknown.
+//
+//nolint:gocyclo
+func (c *AggregateFunction) stateReader() func() error {
+	if len(c.argTypes) > 1 && c.function != "uniqExact" {
+		return nil
+	}
+	var argType []byte
+	var argSize int
+	if len(c.argTypes) == 1 {
+		argType = c.argTypes[0]
+		argSize = fixedTypeSize(argType)
+	}
+	switch c.function {
+	case "count":
+		return func() error {
+			_, err := c.readUvarint()
+			return err
+		}
+	case "sum":
+		size := sumStateSize(argType, argSize)
+		if size == 0 {
+			return nil
+		}
+		return func() error {
+			return c.readFixed(size)
+		}
+	case "sumWithOverflow":
+		if argSize == 0 {
+			return nil
+		}
+		return func() error {
+			return c.readFixed(argSize)
+		}
+	case "min", "max", "any", "anyLast":
+		if helper.IsString(argType) {
+			return c.readSingleString
+		}
+		if argSize == 0 {
+			return nil
+		}
+		return func() error {
+			has, err := c.readByte()
+			if err != nil || has == 0 {
+				return err
+			}
+			return c.readFixed(argSize)
+		}
+	case "uniq":
+		return func() error {
+			// skip degree
+			if _, err := c.readByte(); err != nil {
+				return err
+			}
+			n, err := c.readUvarint()
+			if err != nil {
+				return err
+			}
+			// the hash values are UInt32
+			return c.readFixed(int(n) * 4)
+		}
+	case "uniqExact":
+		// the strings, multiple arguments and the other types are stored by UInt128 hash
+		keySize := 16
+		if len(c.argTypes) == 1 && argSize > 0 && !helper.IsFixedString(argType) && !helper.IsDecimal(argType) {
+			keySize = argSize
+		}
+		return func() error {
+			n, err := c.readUvarint()
+			if err != nil {
+				return err
+			}
+			return c.readFixed(int(n) * keySize)
+		}
+	case "groupBitmap":
+		if argSize == 0 {
+			return nil
+		}
+		return func() error {
+			kind, err := c.readByte()
+			if err != nil {
+				return err
+			}
+			n, err := c.readUvarint()
+			if err != nil {
+				return err
+			}
+			if kind == 0 {
+				// small set of values
+				return c.readFixed(int(n) * argSize)
+			}
+			// serialized roaring bitmap
+			return c.readFixed(int(n))
+		}
+	}
+	return nil
+}
+
+// readSingleString read the state of min/max/any of String. (Int32 size (-1 for empty state) and the data)
+func (c *AggregateFunction) readSingleString() error {
+	start := len(c.vals)
+	if err := c.readFixed(4); err != nil {
+		return err
+	}
+	size := int32(binary.LittleEndian.Uint32(c.vals[start:]))
+	if size <= 0 {
+		return nil
+	}
+	return c.readFixed(int(size))
+}
+
+// readFixed read n bytes of the state.
+//
+// n comes from the server data. So the buffer is grown by chunks to not allocate more than the read data.
+func (c *AggregateFunction) readFixed(n int) error {
+	if n < 0 {
+		//nolint:goerr113
+		return fmt.Errorf("aggregate function: invalid state size: %d", n)
+	}
+	for n > 0 {
+		chunk := n
+		if chunk > aggregateFunctionReadChunk {
+			chunk = aggregateFunctionReadChunk
+		}
+		start := len(c.vals)
+		if cap(c.vals)-start < chunk {
+			vals := make([]byte, start, 2*cap(c.vals)+chunk)
+			copy(vals, c.vals)
+			c.vals = vals
+		}
+		c.vals = c.vals[:start+chunk]
+		if _, err := c.r.Read(c.vals[start:]); err != nil {
+			return err
+		}
+		n -= chunk
+	}
+	return nil
+}
+
+func (c *AggregateFunction) readByte() (byte, error) {
+	b, err := c.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	c.vals = append(c.vals, b)
+	return b, nil
+}
+
+func (c *AggregateFunction) readUvarint() (uint64, error) {
+	var x uint64
+	var s uint
+	for i := 0; i < binary.MaxVarintLen64; i++ {
+		b, err := c.readByte()
+		if err != nil {
+			return 0, err
+		}
+		if b < 0x80 {
+			return x | uint64(b)<<s, nil
+		}
+		x |= uint64(b&0x7f) << s
+		s += 7
+	}
+	return 0, fmt.Errorf("varint overflows a 64-bit integer")
+}
+
+func (c *AggregateFunction) ColumnType() string {
+	str := helper.AggregateFunctionStr
+	if c.version != "" {
+		str += c.version + ", "
+	}
+	str += c.function
+	for _, t := range c.argTypes {
+		str += ", " + string(t)
+	}
+	return str + ")"
+}
+
+// WriteTo write data to ClickHouse.
+// it uses internally
+func (c *AggregateFunction) WriteTo(w io.Writer) (int64, error) {
+	nw, err := w.Write(c.writerData)
+	return int64(nw), err
+}
+
+// HeaderWriter writes header data to writer
+// it uses internally
+func (c *AggregateFunction) HeaderWriter(w *readerwriter.Writer) {
+}
+
+func (c *AggregateFunction) Elem(arrayLevel int) ColumnBasic {
+	if arrayLevel > 0 {
+		return c.Array().elem(arrayLevel - 1)
+	}
+	return c
+}
+
+// fixedTypeSize return the byte size of the fixed size ClickHouse types. it returns 0 for the other types.
+func fixedTypeSize(chType []byte) int {
+	if size, ok := chColumnByteSize[string(chType)]; ok {
+		return size
+	}
+	switch {
+	case helper.IsEnum8(chType):
+		return Uint8Size
+	case helper.IsEnum16(chType):
+		return Uint16Size
+	case helper.IsDateTimeWithParam(chType):
+		return 4
+	case helper.IsDateTime64(chType):
+		return 8
+	case helper.IsFixedString(chType):
+		size, _ := strconv.Atoi(string(chType[helper.FixedStringStrLen : len(chType)-1]))
+		return size
+	case helper.IsDecimal(chType):
+		parts := bytes.Split(chType[helper.DecimalStrLen:len(chType)-1], []byte(", "))
+		precision, _ := strconv.Atoi(string(parts[0]))
+		switch {
+		case precision >= 1 && precision <= 9:
+			return 4
+		case precision >= 10 && precision <= 18:
+			return 8
+		case precision >= 19 && precision <= 38:
+			return 16
+		case precision >= 39 && precision <= 76:
+			return 32
+		}
+	}
+	return 0
+}
+
+// sumStateSize return the byte size of the result type of sum. it returns 0 for unsupported types.
+func sumStateSize(argType []byte, argSize int) int {
+	switch {
+	case helper.IsDecimal(argType):
+		if argSize == 32 {
+			return 32
+		}
+		return 16
+	case helper.IsFixedString(argType), helper.IsEnum8(argType), helper.IsEnum16(argType):
+		return 0
+	}
+	if _, ok := chColumnByteSize[string(argType)]; !ok {
+		return 0
+	}
+	switch string(argType) {
+	case "Int128", "UInt128", "Int256", "UInt256":
+		return argSize
+	case "Int8", "Int16", "Int32", "Int64", "UInt8", "UInt16", "UInt32", "UInt64", "Float32", "Float64":
+		return 8
+	}
+	return 0
+}
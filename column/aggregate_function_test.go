@@ -0,0 +1,132 @@
+package column_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2"
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+	"github.com/vahid-sohrabloo/chconn/v2/internal/readerwriter"
+)
+
+func TestAggregateFunction(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	conn, err := chconn.Connect(context.Background(), connString)
+	require.NoError(t, err)
+
+	for _, table := range []string{"test_aggregate_function", "test_aggregate_function_copy"} {
+		err = conn.Exec(context.Background(), `DROP TABLE IF EXISTS `+table)
+		require.NoError(t, err)
+
+		err = conn.Exec(context.Background(), `CREATE TABLE `+table+` (
+			id UInt8,
+			uniq AggregateFunction(uniq, UInt64),
+			uniq_exact AggregateFunction(uniqExact, String),
+			count AggregateFunction(count),
+			sum AggregateFunction(sum, UInt32),
+			max AggregateFunction(max, String),
+			bitmap AggregateFunction(groupBitmap, UInt32)
+		) Engine=Memory`)
+		require.NoError(t, err)
+	}
+
+	err = conn.Exec(context.Background(), `INSERT INTO test_aggregate_function SELECT
+			number % 2 AS id,
+			uniqState(number),
+			uniqExactState(toString(number)),
+			countState(),
+			sumState(toUInt32(number)),
+			maxState(toString(number)),
+			groupBitmapState(toUInt32(number))
+		FROM numbers(1000) GROUP BY id`)
+	require.NoError(t, err)
+
+	selectStmt, err := conn.Select(context.Background(), `SELECT * FROM test_aggregate_function ORDER BY id`)
+	require.NoError(t, err)
+
+	autoColumns := selectStmt.Columns()
+	require.Len(t, autoColumns, 7)
+	states := make([][][]byte, len(autoColumns))
+	for selectStmt.Next() {
+		for i, col := range autoColumns[1:] {
+			states[i+1] = col.(*column.AggregateFunction).Read(states[i+1])
+		}
+	}
+	require.NoError(t, selectStmt.Err())
+	assert.Equal(t, "uniq", autoColumns[1].(*column.AggregateFunction).Function())
+
+	columns := []column.ColumnBasic{column.New[uint8]()}
+	columns[0].(*column.Base[uint8]).Append(0, 1)
+	for _, data := range states[1:] {
+		col := column.NewAggregateFunction()
+		col.Append(data...)
+		columns = append(columns, col)
+	}
+	err = conn.Insert(context.Background(), `INSERT INTO test_aggregate_function_copy VALUES`, columns...)
+	require.NoError(t, err)
+
+	var results [2][]string
+	for i, table := range []string{"test_aggregate_function", "test_aggregate_function_copy"} {
+		col := column.NewString()
+		selectStmt, err = conn.Select(context.Background(), `SELECT toString(tuple(
+				finalizeAggregation(uniq),
+				finalizeAggregation(uniq_exact),
+				finalizeAggregation(count),
+				finalizeAggregation(sum),
+				finalizeAggregation(max),
+				finalizeAggregation(bitmap)
+			)) FROM `+table+` ORDER BY id`, col)
+		require.NoError(t, err)
+		for selectStmt.Next() {
+			results[i] = col.Read(results[i])
+		}
+		require.NoError(t, selectStmt.Err())
+	}
+	require.Len(t, results[0], 2)
+	assert.Equal(t, results[0], results[1])
+}
+
+func TestAggregateFunctionValidate(t *testing.T) {
+	t.Parallel()
+
+	col := column.NewAggregateFunction()
+	col.SetType([]byte("AggregateFunction()"))
+	require.Error(t, col.Validate())
+
+	col.SetType([]byte("AggregateFunction(1, uniq, UInt64)"))
+	require.NoError(t, col.Validate())
+	assert.Equal(t, "uniq", col.Function())
+	assert.Equal(t, "AggregateFunction(1, uniq, UInt64)", col.ColumnType())
+
+	col.SetType([]byte("AggregateFunction(sum, UInt32)"))
+	require.NoError(t, col.Validate())
+	assert.Equal(t, "AggregateFunction(sum, UInt32)", col.ColumnType())
+
+	col.SetType([]byte("UInt64"))
+	var errType *column.ErrInvalidType
+	assert.ErrorAs(t, col.Validate(), &errType)
+}
+
+func TestAggregateFunctionReadLargeState(t *testing.T) {
+	t.Parallel()
+
+	col := column.NewAggregateFunction()
+	col.SetType([]byte("AggregateFunction(uniq, UInt64)"))
+	require.NoError(t, col.Validate())
+
+	// a uniq state with the degree and a very large count of the hash values without the data
+	data := make([]byte, 1+binary.MaxVarintLen64)
+	data[0] = 1
+	n := binary.PutUvarint(data[1:], 1<<40)
+	err := col.ReadRaw(1, readerwriter.NewReader(bytes.NewReader(data[:1+n])))
+	assert.ErrorIs(t, err, io.EOF)
+}
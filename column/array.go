@@ -7,6 +7,11 @@ type Array[T any] struct {
 }
 
 // NewArray create a new array column of Array(T) ClickHouse data type
+//
+// Arrays can be nested to any depth, because all the typed arrays are columns of their row type.
+// For example a column of Array(Array(Array(Array(Float32)))):
+//
+//	col := column.NewArray[[][][]float32](column.New[float32]().Array().Array().Array())
 func NewArray[T any](dataColumn Column[T]) *Array[T] {
 	a := &Array[T]{
 		ArrayBase: ArrayBase{
@@ -35,6 +40,22 @@ func (c *Array[T]) Data() [][]T {
 	return values
 }
 
+// DataView get all the data in current block as a slice of views over the block buffer without copying.
+// e.g. `[][]float32` of `Array(Float32)` with `New[float32]().Array()`
+//
+// NOTE: the return slice only valid in current block, if you want to use it after, you should copy it. or use Read
+func (c *Array[T]) DataView() [][]T {
+	values := make([][]T, c.offsetColumn.numRow)
+	offsets := c.Offsets()
+	var lastOffset uint64
+	columnData := c.getColumnData()
+	for i, offset := range offsets {
+		values[i] = columnData[lastOffset:offset:offset]
+		lastOffset = offset
+	}
+	return values
+}
+
 // Read reads all the data in current block and append to the input.
 func (c *Array[T]) Read(value [][]T) [][]T {
 	offsets := c.Offsets()
@@ -61,6 +82,18 @@ func (c *Array[T]) Row(row int) []T {
 	return val
 }
 
+// RowView return the value of given row as a view over the block buffer without copying.
+//
+// NOTE: Row number start from zero. data is valid only in the current block.
+func (c *Array[T]) RowView(row int) []T {
+	var lastOffset uint64
+	if row != 0 {
+		lastOffset = c.offsetColumn.Row(row - 1)
+	}
+	offset := c.offsetColumn.Row(row)
+	return c.getColumnData()[lastOffset:offset:offset]
+}
+
 // Append value for insert
 func (c *Array[T]) Append(v ...[]T) {
 	for _, v := range v {
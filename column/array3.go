@@ -69,7 +69,8 @@ func (c *Array2[T]) Array() *Array3[T] {
 
 func (c *Array3[T]) elem(arrayLevel int) ColumnBasic {
 	if arrayLevel > 0 {
-		panic("array level is too deep")
+		// deeper arrays can not be typed generically. use an untyped array on top of this column
+		return NewArrayBase(c).elem(arrayLevel - 1)
 	}
 	return c
 }
@@ -88,7 +88,8 @@ func (c *Array3Nullable[T]) getColumnData() [][][]*T {
 
 func (c *Array3Nullable[T]) elem(arrayLevel int) ColumnBasic {
 	if arrayLevel > 0 {
-		panic("array level is too deep")
+		// deeper arrays can not be typed generically. use an untyped array on top of this column
+		return NewArrayBase(c).elem(arrayLevel - 1)
 	}
 	return c
 }
@@ -12,7 +12,9 @@ import (
 
 // ArrayBase is a column of Array(T) ClickHouse data type
 //
-// ArrayBase is a base class for other arrays or use for none generic use
+// ArrayBase is a base class for other arrays or use for none generic use.
+// The automatic column inference use it for the arrays that are deeper than three levels.
+// The rows are available by RowAny and the typed inner column by Column.
 type ArrayBase struct {
 	column
 	offsetColumn *Base[uint64]
@@ -119,6 +121,21 @@ func (c *ArrayBase) Column() ColumnBasic {
 	return c.dataColumn
 }
 
+// RowAny return the value of given row as a slice of any.
+// NOTE: Row number start from zero
+func (c *ArrayBase) RowAny(row int) any {
+	var start uint64
+	if row > 0 {
+		start = c.offsetColumn.Row(row - 1)
+	}
+	end := c.offsetColumn.Row(row)
+	val := make([]any, 0, end-start)
+	for i := start; i < end; i++ {
+		val = append(val, rowAny(c.dataColumn, int(i)))
+	}
+	return val
+}
+
 func (c *ArrayBase) Validate() error {
 	chType := helper.FilterSimpleAggregate(c.chType)
 	switch {
@@ -128,6 +145,10 @@ func (c *ArrayBase) Validate() error {
 		chType = helper.PolygonMainTypeStr
 	case helper.IsMultiPolygon(chType):
 		chType = helper.MultiPolygonMainTypeStr
+	case helper.IsLineString(chType):
+		chType = helper.LineStringMainTypeStr
+	case helper.IsMultiLineString(chType):
+		chType = helper.MultiLineStringMainTypeStr
 	}
 
 	chType = helper.NestedToArrayType(chType)
@@ -0,0 +1,84 @@
+package column_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2"
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+)
+
+func TestArrayDeepNesting(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	conn, err := chconn.Connect(context.Background(), connString)
+	require.NoError(t, err)
+
+	err = conn.Exec(context.Background(), `DROP TABLE IF EXISTS test_array_deep`)
+	require.NoError(t, err)
+
+	err = conn.Exec(context.Background(), `CREATE TABLE test_array_deep (
+			id UInt8,
+			embedding Array(Array(Array(Array(Float32))))
+		) Engine=Memory`)
+	require.NoError(t, err)
+
+	colID := column.New[uint8]()
+	col := column.NewArray[[][][]float32](column.New[float32]().Array().Array().Array())
+	data := [][][][][]float32{
+		{{{{1, 2}, {3}}}, {{{4}}}},
+		{},
+		{{{{5, 6, 7}}, {}}},
+	}
+	for i, v := range data {
+		colID.Append(uint8(i))
+		col.Append(v)
+	}
+
+	err = conn.Insert(context.Background(), `INSERT INTO test_array_deep (id, embedding) VALUES`, colID, col)
+	require.NoError(t, err)
+
+	// typed read
+	colRead := column.NewArray[[][][]float32](column.New[float32]().Array().Array().Array())
+	selectStmt, err := conn.Select(context.Background(), `SELECT embedding FROM test_array_deep ORDER BY id`, colRead)
+	require.NoError(t, err)
+	var typedData [][][][][]float32
+	for selectStmt.Next() {
+		typedData = colRead.Read(typedData)
+	}
+	require.NoError(t, selectStmt.Err())
+	require.Len(t, typedData, len(data))
+	assert.Equal(t, data[0], typedData[0])
+	assert.Len(t, typedData[1], 0)
+	assert.Equal(t, data[2], typedData[2])
+
+	// automatic inference
+	selectStmt, err = conn.Select(context.Background(), `SELECT embedding FROM test_array_deep ORDER BY id`)
+	require.NoError(t, err)
+	autoColumns := selectStmt.Columns()
+	require.Len(t, autoColumns, 1)
+	colAuto, ok := autoColumns[0].(*column.ArrayBase)
+	require.True(t, ok)
+	_, ok = colAuto.Column().(*column.Array3[float32])
+	require.True(t, ok)
+	var anyData []any
+	for selectStmt.Next() {
+		for i := 0; i < colAuto.NumRow(); i++ {
+			anyData = append(anyData, colAuto.RowAny(i))
+		}
+	}
+	require.NoError(t, selectStmt.Err())
+	assert.Equal(t, []any{
+		[]any{
+			[]any{[]any{[]any{float32(1), float32(2)}, []any{float32(3)}}},
+			[]any{[]any{[]any{float32(4)}}},
+		},
+		[]any{},
+		[]any{[]any{[]any{[]any{float32(5), float32(6), float32(7)}}, []any{}}},
+	}, anyData)
+}
@@ -45,6 +45,12 @@ func (c *Base[T]) Row(row int) T {
 	return *(*T)(unsafe.Pointer(&c.b[i]))
 }
 
+// RowAny return the value of given row as any.
+// NOTE: Row number start from zero
+func (c *Base[T]) RowAny(row int) any {
+	return c.Row(row)
+}
+
 // Append value for insert
 func (c *Base[T]) Append(v ...T) {
 	c.values = append(c.values, v...)
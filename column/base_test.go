@@ -25,6 +25,44 @@ func TestBool(t *testing.T) {
 	})
 }
 
+func TestBoolType(t *testing.T) {
+	testColumn(t, true, "Bool", "bool_type", func(i int) bool {
+		return i%2 == 0
+	}, func(i int) bool {
+		return i%2 == 1
+	})
+}
+
+func TestBoolTypeAutoColumn(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	conn, err := chconn.Connect(context.Background(), connString)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	selectStmt, err := conn.Select(context.Background(), `SELECT
+		true::Bool,
+		false::Nullable(Bool),
+		[true, false]::Array(Bool),
+		[true, NULL]::Array(Nullable(Bool))`)
+	require.NoError(t, err)
+	autoColumns := selectStmt.Columns()
+	require.Len(t, autoColumns, 4)
+	assert.IsType(t, column.NewBool(), autoColumns[0])
+	assert.Equal(t, column.NewBool().Nullable().ColumnType(), autoColumns[1].ColumnType())
+	assert.Equal(t, column.NewBool().Array().ColumnType(), autoColumns[2].ColumnType())
+	assert.Equal(t, column.NewBool().Nullable().Array().ColumnType(), autoColumns[3].ColumnType())
+
+	require.True(t, selectStmt.Next())
+	assert.True(t, autoColumns[0].(*column.Base[bool]).Row(0))
+	for selectStmt.Next() {
+	}
+	require.NoError(t, selectStmt.Err())
+	selectStmt.Close()
+}
+
 func TestUint8(t *testing.T) {
 	testColumn(t, true, "UInt8", "uint8", func(i int) uint8 {
 		return uint8(i)
@@ -23,18 +23,21 @@ var chColumnByteSize = map[string]int{
 	"UInt256":    32,
 	"Float32":    4,
 	"Float64":    8,
+	"BFloat16":   2,
+	"Bool":       1,
 	"Date":       2,
 	"Date32":     4,
 	"DateTime":   4,
 	"DateTime64": 8,
+	"Time":       4,
 	"UUID":       16,
 	"IPv4":       4,
 	"IPv6":       16,
 }
 
 var byteChColumnType = map[int]string{
-	1:  "Int8|UInt8|Enum8",
-	2:  "Int16|UInt16|Enum16|Date",
+	1:  "Int8|UInt8|Enum8|Bool",
+	2:  "Int16|UInt16|Enum16|Date|BFloat16",
 	4:  "Int32|UInt32|Float32|Decimal32|Date32|DateTime|IPv4",
 	8:  "Int64|UInt64|Float64|Decimal64|DateTime64",
 	16: "Int128|UInt128|Decimal128|IPv6|UUID",
@@ -71,6 +74,12 @@ func (c *Base[T]) Validate() error {
 	if ok, err := c.checkDateTime64(chType); ok {
 		return err
 	}
+	if ok, err := c.checkTime64(chType); ok {
+		return err
+	}
+	if ok, err := c.checkInterval(chType); ok {
+		return err
+	}
 	if ok, err := c.checkFixedString(chType); ok {
 		return err
 	}
@@ -145,6 +154,30 @@ func (c *Base[T]) checkDateTime64(chType []byte) (bool, error) {
 	return false, nil
 }
 
+func (c *Base[T]) checkTime64(chType []byte) (bool, error) {
+	if helper.IsTime64(chType) {
+		if c.size != 8 {
+			return true, &ErrInvalidType{
+				column: c,
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (c *Base[T]) checkInterval(chType []byte) (bool, error) {
+	if _, ok := intervalUnits[string(chType)]; ok {
+		if c.size != 8 {
+			return true, &ErrInvalidType{
+				column: c,
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
 func (c *Base[T]) checkFixedString(chType []byte) (bool, error) {
 	if helper.IsFixedString(chType) {
 		size, err := strconv.Atoi(string(chType[helper.FixedStringStrLen : len(chType)-1]))
@@ -0,0 +1,104 @@
+package column
+
+import (
+	"unsafe"
+
+	"github.com/vahid-sohrabloo/chconn/v2/internal/helper"
+	"github.com/vahid-sohrabloo/chconn/v2/types"
+)
+
+// BFloat16 is a column of BFloat16 ClickHouse data type.
+// it converts the values to and from float32. but if you want to work with the raw data
+// you can directly use `Column` (`New[types.BFloat16]()`)
+type BFloat16 struct {
+	Base[types.BFloat16]
+}
+
+// NewBFloat16 create a new column of BFloat16 ClickHouse data type
+func NewBFloat16() *BFloat16 {
+	return &BFloat16{
+		Base: Base[types.BFloat16]{
+			size: 2,
+		},
+	}
+}
+
+// Data get all the data in current block as a slice.
+func (c *BFloat16) Data() []float32 {
+	return c.Read(make([]float32, 0, c.numRow))
+}
+
+// Read reads all the data in current block and append to the input.
+func (c *BFloat16) Read(value []float32) []float32 {
+	for _, v := range c.Base.Data() {
+		value = append(value, v.Float32())
+	}
+	return value
+}
+
+// Row return the value of given row
+// NOTE: Row number start from zero
+func (c *BFloat16) Row(row int) float32 {
+	i := row * c.size
+	return (*(*types.BFloat16)(unsafe.Pointer(&c.b[i]))).Float32()
+}
+
+// RowAny return the value of given row as any.
+// NOTE: Row number start from zero
+func (c *BFloat16) RowAny(row int) any {
+	return c.Row(row)
+}
+
+// Append value for insert
+func (c *BFloat16) Append(v ...float32) {
+	for _, v := range v {
+		c.values = append(c.values, types.BFloat16FromFloat32(v))
+	}
+	c.numRow += len(v)
+}
+
+// Array return a Array type for this column
+func (c *BFloat16) Array() *Array[float32] {
+	return NewArray[float32](c)
+}
+
+// Nullable return a nullable type for this column
+func (c *BFloat16) Nullable() *Nullable[float32] {
+	return NewNullable[float32](c)
+}
+
+// LC return a low cardinality type for this column
+func (c *BFloat16) LC() *LowCardinality[float32] {
+	return NewLC[float32](c)
+}
+
+// LowCardinality return a low cardinality type for this column
+func (c *BFloat16) LowCardinality() *LowCardinality[float32] {
+	return NewLC[float32](c)
+}
+
+func (c *BFloat16) Validate() error {
+	if string(helper.FilterSimpleAggregate(c.chType)) != "BFloat16" {
+		return &ErrInvalidType{
+			column: c,
+		}
+	}
+	return nil
+}
+
+func (c *BFloat16) ColumnType() string {
+	return "BFloat16"
+}
+
+func (c *BFloat16) Elem(arrayLevel int, nullable, lc bool) ColumnBasic {
+	if nullable {
+		return c.Nullable().elem(arrayLevel, lc)
+	}
+	if lc {
+		return c.LowCardinality().elem(arrayLevel)
+	}
+	if arrayLevel > 0 {
+		return c.Array().elem(arrayLevel - 1)
+	}
+	return c
+}
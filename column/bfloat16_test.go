@@ -0,0 +1,86 @@
+package column_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2"
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+)
+
+func TestBFloat16AndFloat32Vector(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	conn, err := chconn.Connect(context.Background(), connString)
+	require.NoError(t, err)
+
+	err = conn.Exec(context.Background(), `DROP TABLE IF EXISTS test_bfloat16`)
+	require.NoError(t, err)
+
+	err = conn.Exec(context.Background(), `CREATE TABLE test_bfloat16 (
+			id UInt8,
+			bf16 BFloat16,
+			embedding_bf16 Array(BFloat16),
+			embedding Array(Float32)
+		) Engine=Memory`)
+	require.NoError(t, err)
+
+	colID := column.New[uint8]()
+	colBF16 := column.NewBFloat16()
+	colEmbeddingBF16 := column.NewBFloat16().Array()
+	colEmbedding := column.New[float32]().Array()
+
+	embeddings := [][]float32{{0.5, -1.25, 3}, {}, {1024, 0.0078125}}
+	for i, v := range embeddings {
+		colID.Append(uint8(i))
+		colBF16.Append(float32(i) + 0.5)
+		colEmbeddingBF16.Append(v)
+		colEmbedding.Append(v)
+	}
+
+	err = conn.Insert(context.Background(), `INSERT INTO test_bfloat16 VALUES`,
+		colID,
+		colBF16,
+		colEmbeddingBF16,
+		colEmbedding,
+	)
+	require.NoError(t, err)
+
+	selectStmt, err := conn.Select(context.Background(), `SELECT
+			bf16,
+			embedding_bf16,
+			embedding
+		FROM test_bfloat16 ORDER BY id`)
+	require.NoError(t, err)
+
+	autoColumns := selectStmt.Columns()
+	require.Len(t, autoColumns, 3)
+	colBF16Read, ok := autoColumns[0].(*column.BFloat16)
+	require.True(t, ok)
+	colEmbeddingBF16Read, ok := autoColumns[1].(*column.Array[float32])
+	require.True(t, ok)
+	colEmbeddingRead, ok := autoColumns[2].(*column.Array[float32])
+	require.True(t, ok)
+
+	var bf16Data []float32
+	var embeddingBF16Data, embeddingData [][]float32
+	for selectStmt.Next() {
+		bf16Data = colBF16Read.Read(bf16Data)
+		embeddingBF16Data = colEmbeddingBF16Read.Read(embeddingBF16Data)
+		// the views are only valid in the current block
+		for i, v := range colEmbeddingRead.DataView() {
+			assert.Equal(t, colEmbeddingRead.RowView(i), v)
+			embeddingData = append(embeddingData, append([]float32{}, v...))
+		}
+	}
+	require.NoError(t, selectStmt.Err())
+
+	assert.Equal(t, []float32{0.5, 1.5, 2.5}, bf16Data)
+	assert.Equal(t, embeddings, embeddingBF16Data)
+	assert.Equal(t, embeddings, embeddingData)
+}
@@ -0,0 +1,8 @@
+package column
+
+// NewBool create a new column of Bool ClickHouse data type
+//
+// Bool is stored as UInt8 (0 or 1) in ClickHouse, so it can be also used for UInt8 and Int8.
+func NewBool() *Base[bool] {
+	return New[bool]()
+}
@@ -114,3 +114,22 @@ func (c *column) SetName(v []byte) {
 func (c *column) SetType(v []byte) {
 	c.chType = v
 }
+
+type rowAnyColumn interface {
+	RowAny(row int) any
+}
+
+// rowAny return the value of given row of the column as any.
+//
+// It returns nil if the column does not support it.
+func rowAny(c ColumnBasic, row int) any {
+	if col, ok := c.(rowAnyColumn); ok {
+		return col.RowAny(row)
+	}
+	return nil
+}
+
+// ColumnByType create a column for the given ClickHouse type.
+//
+// It uses by the columns that their inner types are only known at read time (e.g. JSON).
+type ColumnByType func(chType []byte) (ColumnBasic, error)
@@ -114,6 +114,12 @@ func (c *Date[T]) Row(row int) time.Time {
 	return (*(*T)(unsafe.Pointer(&c.b[i]))).ToTime(c.Location(), c.precision)
 }
 
+// RowAny return the value of given row as any.
+// NOTE: Row number start from zero
+func (c *Date[T]) RowAny(row int) any {
+	return c.Row(row)
+}
+
 // Append value for insert
 func (c *Date[T]) Append(v ...time.Time) {
 	var val T
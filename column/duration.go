@@ -0,0 +1,254 @@
+package column
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+	"unsafe"
+
+	"github.com/vahid-sohrabloo/chconn/v2/internal/helper"
+)
+
+// intervalUnits is the duration of the units of the interval types.
+// the units that do not have a fixed duration are zero.
+var intervalUnits = map[string]time.Duration{
+	"IntervalNanosecond":  time.Nanosecond,
+	"IntervalMicrosecond": time.Microsecond,
+	"IntervalMillisecond": time.Millisecond,
+	"IntervalSecond":      time.Second,
+	"IntervalMinute":      time.Minute,
+	"IntervalHour":        time.Hour,
+	"IntervalDay":         24 * time.Hour,
+	"IntervalWeek":        7 * 24 * time.Hour,
+	"IntervalMonth":       0,
+	"IntervalQuarter":     0,
+	"IntervalYear":        0,
+}
+
+// DurationType is an interface to handle convert between time.Duration and T.
+type DurationType[T any] interface {
+	comparable
+	FromDuration(val, unit time.Duration) T
+	ToDuration(unit time.Duration) time.Duration
+}
+
+// Duration is a column of ClickHouse time and interval types (Time, Time64, Interval*).
+// it is a wrapper of time.Duration. but if you want to work with the raw data
+// you can directly use `Column` (`New[T]()`)
+//
+// `int32` or `types.Time` or any 32 bits data types For `Time`.
+//
+// `int64` or `types.Time64` or any 64 bits data types For `Time64`
+//
+// `int64` or `types.Interval` or any 64 bits data types For `Interval*`
+//
+// IntervalMonth, IntervalQuarter and IntervalYear do not have a fixed duration and only can be used with `New[T]()`
+type Duration[T DurationType[T]] struct {
+	Base[T]
+	unit time.Duration
+	// durations is the appended values. they are converted to the raw data on write,
+	// because the unit may be set from the ClickHouse data type after Append.
+	durations []time.Duration
+}
+
+// NewDuration create a new column of ClickHouse time and interval types (Time, Time64, Interval*).
+// it is a wrapper of time.Duration. but if you want to work with the raw data
+// you can directly use `Column` (`New[T]()`)
+//
+// `int32` or `types.Time` or any 32 bits data types For `Time`.
+//
+// `int64` or `types.Time64` or any 64 bits data types For `Time64`
+//
+// `int64` or `types.Interval` or any 64 bits data types For `Interval*`
+//
+// The precision of `Time64` and the unit of `Interval*` set automatically from the ClickHouse data type if not set.
+func NewDuration[T DurationType[T]]() *Duration[T] {
+	var tmpValue T
+	size := int(unsafe.Sizeof(tmpValue))
+	return &Duration[T]{
+		Base: Base[T]{
+			size: size,
+		},
+	}
+}
+
+// SetPrecision set the precision of the time.Duration. Only use for `Time64`
+func (c *Duration[T]) SetPrecision(precision int) *Duration[T] {
+	c.unit = precisionUnit(precision)
+	return c
+}
+
+// SetUnit set the duration of the unit of the raw data. e.g. time.Minute for `IntervalMinute`
+func (c *Duration[T]) SetUnit(unit time.Duration) *Duration[T] {
+	c.unit = unit
+	return c
+}
+
+// Unit get the duration of the unit of the raw data
+func (c *Duration[T]) Unit() time.Duration {
+	return c.unit
+}
+
+// Data get all the data in current block as a slice.
+func (c *Duration[T]) Data() []time.Duration {
+	values := make([]time.Duration, c.numRow)
+	for i := 0; i < c.numRow; i++ {
+		values[i] = c.Row(i)
+	}
+	return values
+}
+
+// Read reads all the data in current block and append to the input.
+func (c *Duration[T]) Read(value []time.Duration) []time.Duration {
+	if cap(value)-len(value) >= c.NumRow() {
+		value = (value)[:len(value)+c.NumRow()]
+	} else {
+		value = append(value, make([]time.Duration, c.NumRow())...)
+	}
+	val := (value)[len(value)-c.NumRow():]
+	for i := 0; i < c.NumRow(); i++ {
+		val[i] = c.Row(i)
+	}
+	return value
+}
+
+// Row return the value of given row
+// NOTE: Row number start from zero
+func (c *Duration[T]) Row(row int) time.Duration {
+	i := row * c.size
+	return (*(*T)(unsafe.Pointer(&c.b[i]))).ToDuration(c.unit)
+}
+
+// RowAny return the value of given row as any.
+// NOTE: Row number start from zero
+func (c *Duration[T]) RowAny(row int) any {
+	return c.Row(row)
+}
+
+// Append value for insert
+func (c *Duration[T]) Append(v ...time.Duration) {
+	c.durations = append(c.durations, v...)
+	c.numRow += len(v)
+}
+
+func (c *Duration[T]) appendEmpty() {
+	c.Append(0)
+}
+
+// Reset all statuses and buffered data
+func (c *Duration[T]) Reset() {
+	c.Base.Reset()
+	c.durations = c.durations[:0]
+}
+
+// SetWriteBufferSize set write buffer (number of rows)
+// this buffer only used for writing.
+// By setting this buffer, you will avoid allocating the memory several times.
+func (c *Duration[T]) SetWriteBufferSize(row int) {
+	c.Base.SetWriteBufferSize(row)
+	if cap(c.durations) < row {
+		c.durations = make([]time.Duration, 0, row)
+	}
+}
+
+// WriteTo write the appended values with the unit of the column. the unit is set by Validate if it is not set.
+func (c *Duration[T]) WriteTo(w io.Writer) (int64, error) {
+	if c.unit == 0 {
+		//nolint:goerr113
+		return 0, fmt.Errorf("the unit of the duration column %q is not set", c.name)
+	}
+	var val T
+	c.values = c.values[:0]
+	for _, v := range c.durations {
+		c.values = append(c.values, val.FromDuration(v, c.unit))
+	}
+	return c.Base.WriteTo(w)
+}
+
+// Array return a Array type for this column
+func (c *Duration[T]) Array() *Array[time.Duration] {
+	return NewArray[time.Duration](c)
+}
+
+// Nullable return a nullable type for this column
+func (c *Duration[T]) Nullable() *Nullable[time.Duration] {
+	return NewNullable[time.Duration](c)
+}
+
+// LC return a low cardinality type for this column
+func (c *Duration[T]) LC() *LowCardinality[time.Duration] {
+	return NewLC[time.Duration](c)
+}
+
+// LowCardinality return a low cardinality type for this column
+func (c *Duration[T]) LowCardinality() *LowCardinality[time.Duration] {
+	return NewLC[time.Duration](c)
+}
+
+func (c *Duration[T]) Validate() error {
+	if err := c.Base.Validate(); err != nil {
+		return err
+	}
+	chType := helper.FilterSimpleAggregate(c.chType)
+	switch {
+	case helper.IsTime(chType):
+		if c.unit == 0 {
+			c.unit = time.Second
+		}
+	case helper.IsTime64(chType):
+		if c.unit == 0 {
+			precision, err := strconv.Atoi(string(chType[helper.Time64StrLen : len(chType)-1]))
+			if err != nil || precision < 0 || precision > 9 {
+				return &ErrInvalidType{
+					column: c,
+				}
+			}
+			c.unit = precisionUnit(precision)
+		}
+	case helper.IsInterval(chType):
+		unit := intervalUnits[string(chType)]
+		if unit == 0 {
+			return &ErrInvalidType{
+				column: c,
+			}
+		}
+		if c.unit == 0 {
+			c.unit = unit
+		}
+	default:
+		return &ErrInvalidType{
+			column: c,
+		}
+	}
+	return nil
+}
+
+func (c *Duration[T]) ColumnType() string {
+	if c.size == 4 {
+		return "Time"
+	}
+	return "Time64|IntervalNanosecond|...|IntervalWeek"
+}
+
+func (c *Duration[T]) Elem(arrayLevel int, nullable, lc bool) ColumnBasic {
+	if nullable {
+		return c.Nullable().elem(arrayLevel, lc)
+	}
+	if lc {
+		return c.LowCardinality().elem(arrayLevel)
+	}
+	if arrayLevel > 0 {
+		return c.Array().elem(arrayLevel - 1)
+	}
+	return c
+}
+
+// precisionUnit return the duration of a tick with the given precision (number of the digits of the fraction)
+func precisionUnit(precision int) time.Duration {
+	unit := time.Second
+	for i := 0; i < precision; i++ {
+		unit /= 10
+	}
+	return unit
+}
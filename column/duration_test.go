@@ -0,0 +1,141 @@
+package column_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2"
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+	"github.com/vahid-sohrabloo/chconn/v2/internal/readerwriter"
+	"github.com/vahid-sohrabloo/chconn/v2/types"
+)
+
+func TestDuration(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	conn, err := chconn.Connect(context.Background(), connString)
+	require.NoError(t, err)
+
+	err = conn.Exec(context.Background(), `DROP TABLE IF EXISTS test_duration`)
+	require.NoError(t, err)
+
+	set := chconn.Settings{
+		{
+			Name:  "enable_time_time64_type",
+			Value: "1",
+		},
+	}
+	err = conn.ExecWithOption(context.Background(), `CREATE TABLE test_duration (
+			time Time,
+			time64 Time64(3),
+			time64_nullable Nullable(Time64(6)),
+			time_array Array(Time)
+		) Engine=Memory`, &chconn.QueryOptions{
+		Settings: set,
+	})
+	require.NoError(t, err)
+
+	colTime := column.NewDuration[types.Time]()
+	colTime64 := column.NewDuration[types.Time64]()
+	colTime64Nullable := column.NewDuration[types.Time64]().Nullable()
+	colTimeArray := column.NewDuration[types.Time]().Array()
+
+	timeInsert := []time.Duration{time.Hour + 2*time.Minute + 3*time.Second, -time.Second}
+	time64Insert := []time.Duration{1500 * time.Millisecond, -time.Millisecond}
+	time64NullableInsert := []*time.Duration{nil, &time64Insert[0]}
+	timeArrayInsert := [][]time.Duration{{time.Second, time.Minute}, {}}
+
+	colTime.Append(timeInsert...)
+	colTime64.Append(time64Insert...)
+	colTime64Nullable.AppendP(time64NullableInsert...)
+	colTimeArray.Append(timeArrayInsert...)
+
+	err = conn.Insert(context.Background(), `INSERT INTO test_duration VALUES`,
+		colTime,
+		colTime64,
+		colTime64Nullable,
+		colTimeArray,
+	)
+	require.NoError(t, err)
+
+	selectStmt, err := conn.SelectWithOption(context.Background(), `SELECT
+			time,
+			time64,
+			time64_nullable,
+			time_array,
+			toIntervalMinute(time64 > 0) AS interval
+		FROM test_duration`,
+		&chconn.QueryOptions{
+			Settings:  set,
+			UseGoTime: true,
+		},
+	)
+	require.NoError(t, err)
+
+	autoColumns := selectStmt.Columns()
+	require.Len(t, autoColumns, 5)
+
+	var timeData, time64Data, intervalData []time.Duration
+	var time64NullableData []*time.Duration
+	var timeArrayData [][]time.Duration
+	for selectStmt.Next() {
+		timeData = autoColumns[0].(*column.Duration[types.Time]).Read(timeData)
+		time64Data = autoColumns[1].(*column.Duration[types.Time64]).Read(time64Data)
+		time64NullableData = autoColumns[2].(*column.Nullable[time.Duration]).ReadP(time64NullableData)
+		timeArrayData = autoColumns[3].(*column.Array[time.Duration]).Read(timeArrayData)
+		intervalData = autoColumns[4].(*column.Duration[types.Interval]).Read(intervalData)
+	}
+	require.NoError(t, selectStmt.Err())
+
+	assert.Equal(t, timeInsert, timeData)
+	assert.Equal(t, time64Insert, time64Data)
+	assert.Equal(t, time64NullableInsert, time64NullableData)
+	assert.Equal(t, timeArrayInsert, timeArrayData)
+	assert.Equal(t, []time.Duration{time.Minute, 0}, intervalData)
+}
+
+func TestDurationAppendBeforeType(t *testing.T) {
+	t.Parallel()
+
+	// the values are appended before the insert header sets the ClickHouse type
+	colTime := column.NewDuration[types.Time]()
+	colTime.Append(time.Second, -time.Minute)
+	colTime64 := column.NewDuration[types.Time64]()
+	colTime64.Append(1500*time.Millisecond, 0)
+	// the empty value of a nullable row
+	colTime64.Nullable().AppendP(nil)
+
+	colTime.SetType([]byte("Time"))
+	require.NoError(t, colTime.Validate())
+	colTime64.SetType([]byte("Time64(3)"))
+	require.NoError(t, colTime64.Validate())
+
+	var buf bytes.Buffer
+	_, err := colTime.WriteTo(&buf)
+	require.NoError(t, err)
+	colRead := column.NewDuration[types.Time]()
+	colRead.SetType([]byte("Time"))
+	require.NoError(t, colRead.Validate())
+	require.NoError(t, colRead.ReadRaw(2, readerwriter.NewReader(&buf)))
+	assert.Equal(t, []time.Duration{time.Second, -time.Minute}, colRead.Data())
+
+	buf.Reset()
+	_, err = colTime64.WriteTo(&buf)
+	require.NoError(t, err)
+	raw := column.New[types.Time64]()
+	require.NoError(t, raw.ReadRaw(3, readerwriter.NewReader(&buf)))
+	assert.Equal(t, []types.Time64{1500, 0, 0}, raw.Data())
+
+	// the unit is required on write
+	colNoType := column.NewDuration[types.Time64]()
+	colNoType.Append(time.Second)
+	_, err = colNoType.WriteTo(&buf)
+	assert.Error(t, err)
+}
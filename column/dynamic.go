@@ -0,0 +1,216 @@
+package column
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/vahid-sohrabloo/chconn/v2/internal/helper"
+	"github.com/vahid-sohrabloo/chconn/v2/internal/readerwriter"
+)
+
+const (
+	dynamicSerializationV1 = 1
+	dynamicSerializationV2 = 2
+)
+
+var errDynamicInsert = errors.New("insert to dynamic column is not supported")
+
+// Dynamic is a column of Dynamic ClickHouse data type
+//
+// The inner types are sent by the server in the header of each block and the columns
+// are created by the ColumnByType function (see `SetColumnByType`). The columns that are created by `Select` set it automatically.
+// Actually, it is a Variant of the types of the current block and the SharedVariant.
+//
+// The values of SharedVariant (the types that exceed the max_types limit) are returned as the raw binary encoded value.
+//
+// NOTE: Dynamic column only supports select.
+type Dynamic struct {
+	column
+	columnByType ColumnByType
+	variant      *Variant
+	columnsCache map[string]ColumnBasic
+}
+
+// NewDynamic create a new column of Dynamic ClickHouse data type
+func NewDynamic() *Dynamic {
+	return &Dynamic{
+		variant:      &Variant{},
+		columnsCache: make(map[string]ColumnBasic),
+	}
+}
+
+// SetColumnByType set the function to create the columns of the inner types.
+func (c *Dynamic) SetColumnByType(f ColumnByType) *Dynamic {
+	c.columnByType = f
+	return c
+}
+
+// Variant return the variant of the current block.
+func (c *Dynamic) Variant() *Variant {
+	return c.variant
+}
+
+// Types return the types of the current block (sorted by name). It includes the SharedVariant.
+//
+// The index of each type is the discriminator of the variant.
+func (c *Dynamic) Types() []string {
+	return c.variant.typeNames
+}
+
+// NumRow return number of row for this block
+func (c *Dynamic) NumRow() int {
+	return c.variant.NumRow()
+}
+
+// RowType return the ClickHouse type of given row. it returns empty string for the null values.
+//
+// NOTE: Row number start from zero
+func (c *Dynamic) RowType(row int) string {
+	return c.variant.RowType(row)
+}
+
+// RowAny return the value of given row as any. it returns nil for the null values.
+//
+// NOTE: Row number start from zero
+func (c *Dynamic) RowAny(row int) any {
+	return c.variant.RowAny(row)
+}
+
+// Array return a Array type for this column
+func (c *Dynamic) Array() *ArrayBase {
+	return NewArrayBase(c)
+}
+
+// Reset all statuses and buffered data
+func (c *Dynamic) Reset() {
+	c.variant.Reset()
+}
+
+// SetWriteBufferSize set write buffer (number of rows)
+func (c *Dynamic) SetWriteBufferSize(int) {
+}
+
+// ReadRaw read raw data from the reader. it runs automatically
+func (c *Dynamic) ReadRaw(num int, r *readerwriter.Reader) error {
+	c.r = r
+	if err := c.variant.ReadRaw(num, r); err != nil {
+		return fmt.Errorf("dynamic: %w", err)
+	}
+	return nil
+}
+
+// HeaderReader reads header data from reader
+// it uses internally
+func (c *Dynamic) HeaderReader(r *readerwriter.Reader, readColumn bool, revision uint64) error {
+	c.r = r
+	err := c.readColumn(readColumn, revision)
+	if err != nil {
+		return err
+	}
+
+	version, err := r.Uint64()
+	if err != nil {
+		return fmt.Errorf("dynamic: read serialization version: %w", err)
+	}
+	switch version {
+	case dynamicSerializationV1:
+		// max_dynamic_types
+		if _, err := r.Uvarint(); err != nil {
+			return fmt.Errorf("dynamic: read max types: %w", err)
+		}
+	case dynamicSerializationV2:
+	default:
+		return fmt.Errorf("dynamic: unsupported serialization version: %d", version)
+	}
+
+	numTypes, err := r.Uvarint()
+	if err != nil {
+		return fmt.Errorf("dynamic: read number of types: %w", err)
+	}
+	typeNames := c.variant.typeNames[:0]
+	for i := uint64(0); i < numTypes; i++ {
+		typeName, err := r.String()
+		if err != nil {
+			return fmt.Errorf("dynamic: read type name: %w", err)
+		}
+		typeNames = append(typeNames, typeName)
+	}
+	// the shared variant is always exists and the variants are sorted by the type name
+	typeNames = append(typeNames, helper.SharedVariantStr)
+	sort.Strings(typeNames)
+	c.variant.typeNames = typeNames
+
+	c.variant.columns = c.variant.columns[:0]
+	for _, typeName := range typeNames {
+		col, err := c.variantColumn(typeName)
+		if err != nil {
+			return err
+		}
+		c.variant.columns = append(c.variant.columns, col)
+	}
+
+	if err := c.variant.HeaderReader(r, false, revision); err != nil {
+		return fmt.Errorf("dynamic: %w", err)
+	}
+	return nil
+}
+
+func (c *Dynamic) variantColumn(typeName string) (ColumnBasic, error) {
+	if col, ok := c.columnsCache[typeName]; ok {
+		return col, nil
+	}
+	var col ColumnBasic
+	if typeName == helper.SharedVariantStr {
+		// the values of the shared variant are the binary encoded type and value
+		col = NewString()
+		col.SetType([]byte(helper.StringStr))
+	} else {
+		if c.columnByType == nil {
+			return nil, fmt.Errorf("dynamic: cannot create column for %s: column factory is not set", typeName)
+		}
+		var err error
+		col, err = c.columnByType([]byte(typeName))
+		if err != nil {
+			return nil, fmt.Errorf("dynamic: %w", err)
+		}
+		col.SetType([]byte(typeName))
+	}
+	if err := col.Validate(); err != nil {
+		return nil, fmt.Errorf("dynamic: %w", err)
+	}
+	c.columnsCache[typeName] = col
+	return col, nil
+}
+
+func (c *Dynamic) Validate() error {
+	if !helper.IsDynamic(c.chType) {
+		return &ErrInvalidType{
+			column: c,
+		}
+	}
+	return nil
+}
+
+func (c *Dynamic) ColumnType() string {
+	return helper.DynamicStr
+}
+
+// WriteTo write data to ClickHouse.
+// it uses internally
+func (c *Dynamic) WriteTo(io.Writer) (int64, error) {
+	return 0, errDynamicInsert
+}
+
+// HeaderWriter writes header data to writer
+// it uses internally
+func (c *Dynamic) HeaderWriter(*readerwriter.Writer) {
+}
+
+func (c *Dynamic) Elem(arrayLevel int) ColumnBasic {
+	if arrayLevel > 0 {
+		return c.Array().elem(arrayLevel - 1)
+	}
+	return c
+}
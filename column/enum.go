@@ -0,0 +1,179 @@
+package column
+
+import (
+	"github.com/vahid-sohrabloo/chconn/v2/internal/helper"
+)
+
+// Enum is a column of Enum8(...) and Enum16(...) ClickHouse data types.
+//
+// The values can be read and appended by the labels (`RowString`, `DataString`, `AppendString`)
+// or by the raw values (`Row`, `Data`, `Append`).
+//
+// The labels are mapped by the ClickHouse type, so on insert the appended labels are converted
+// (and validated) when the insert statement set the type of the column.
+type Enum[T ~int8 | ~int16] struct {
+	Base[T]
+	intToString   map[T]string
+	stringToInt   map[string]T
+	pendingLabels []enumLabel
+}
+
+type enumLabel struct {
+	row   int
+	label string
+}
+
+// NewEnum8 create a new column of Enum8 ClickHouse data type
+func NewEnum8() *Enum[int8] {
+	return &Enum[int8]{
+		Base: Base[int8]{
+			size: Int8Size,
+		},
+	}
+}
+
+// NewEnum16 create a new column of Enum16 ClickHouse data type
+func NewEnum16() *Enum[int16] {
+	return &Enum[int16]{
+		Base: Base[int16]{
+			size: Int16Size,
+		},
+	}
+}
+
+// RowString return the label of given row.
+// NOTE: Row number start from zero
+func (c *Enum[T]) RowString(row int) string {
+	return c.intToString[c.Row(row)]
+}
+
+// RowAny return the label of given row as any.
+// NOTE: Row number start from zero
+func (c *Enum[T]) RowAny(row int) any {
+	return c.RowString(row)
+}
+
+// DataString get the labels of all the data in current block as a slice.
+func (c *Enum[T]) DataString() []string {
+	values := make([]string, c.numRow)
+	for i := 0; i < c.numRow; i++ {
+		values[i] = c.RowString(i)
+	}
+	return values
+}
+
+// ReadString reads the labels of all the data in current block and append to the input.
+func (c *Enum[T]) ReadString(value []string) []string {
+	for i := 0; i < c.numRow; i++ {
+		value = append(value, c.RowString(i))
+	}
+	return value
+}
+
+// AppendString append labels for insert
+//
+// The labels are converted to the values on validate (before sending data to the ClickHouse).
+func (c *Enum[T]) AppendString(v ...string) {
+	for _, label := range v {
+		c.pendingLabels = append(c.pendingLabels, enumLabel{
+			row:   c.numRow,
+			label: label,
+		})
+		c.appendEmpty()
+	}
+}
+
+// Labels return the labels of the enum type by the values.
+//
+// It is available after the type of the column is set and validated.
+func (c *Enum[T]) Labels() map[T]string {
+	return c.intToString
+}
+
+// Reset all statuses and buffered data
+//
+// After each reading, the reading data does not need to be reset. It will be automatically reset.
+//
+// When inserting, buffers are reset only after the operation is successful.
+// If an error occurs, you can safely call insert again.
+func (c *Enum[T]) Reset() {
+	c.Base.Reset()
+	c.pendingLabels = c.pendingLabels[:0]
+}
+
+// Validate check the type of the column and extract the labels of the enum type.
+func (c *Enum[T]) Validate() error {
+	if err := c.Base.Validate(); err != nil {
+		return err
+	}
+	chType := helper.FilterSimpleAggregate(c.chType)
+	var enumData []byte
+	switch {
+	case helper.IsEnum8(chType):
+		enumData = chType[helper.Enum8StrLen : len(chType)-1]
+	case helper.IsEnum16(chType):
+		enumData = chType[helper.Enum16StrLen : len(chType)-1]
+	default:
+		return &ErrInvalidType{
+			column: c,
+		}
+	}
+	intToString, stringToInt, err := helper.ExtractEnum(enumData)
+	if err != nil {
+		return err
+	}
+	c.intToString = make(map[T]string, len(intToString))
+	for k, v := range intToString {
+		c.intToString[T(k)] = v
+	}
+	c.stringToInt = make(map[string]T, len(stringToInt))
+	for k, v := range stringToInt {
+		c.stringToInt[k] = T(v)
+	}
+
+	for _, p := range c.pendingLabels {
+		v, ok := c.stringToInt[p.label]
+		if !ok {
+			return ErrInvalidEnumLabel{
+				column: c,
+				Label:  p.label,
+			}
+		}
+		c.values[p.row] = v
+	}
+	c.pendingLabels = c.pendingLabels[:0]
+	return nil
+}
+
+// Array return a Array type for this column
+func (c *Enum[T]) Array() *Array[T] {
+	return NewArray[T](c)
+}
+
+// Nullable return a nullable type for this column
+func (c *Enum[T]) Nullable() *Nullable[T] {
+	return NewNullable[T](c)
+}
+
+// LC return a low cardinality type for this column
+func (c *Enum[T]) LC() *LowCardinality[T] {
+	return NewLC[T](c)
+}
+
+// LowCardinality return a low cardinality type for this column
+func (c *Enum[T]) LowCardinality() *LowCardinality[T] {
+	return NewLowCardinality[T](c)
+}
+
+func (c *Enum[T]) Elem(arrayLevel int, nullable, lc bool) ColumnBasic {
+	if nullable {
+		return c.Nullable().elem(arrayLevel, lc)
+	}
+	if lc {
+		return c.LowCardinality().elem(arrayLevel)
+	}
+	if arrayLevel > 0 {
+		return c.Array().elem(arrayLevel - 1)
+	}
+	return c
+}
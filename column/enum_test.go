@@ -0,0 +1,95 @@
+package column_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2"
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+)
+
+func TestEnum(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	conn, err := chconn.Connect(context.Background(), connString)
+	require.NoError(t, err)
+
+	err = conn.Exec(context.Background(), `DROP TABLE IF EXISTS test_enum`)
+	require.NoError(t, err)
+
+	err = conn.Exec(context.Background(), `CREATE TABLE test_enum (
+			enum8 Enum8('a' = 1, 'b' = 2, 'it\'s, = ok' = -3),
+			enum16 Enum16('small' = 1, 'large' = 1000),
+			enum8_nullable Nullable(Enum8('a' = 1, 'b' = 2)),
+			enum8_array Array(Enum8('a' = 1, 'b' = 2))
+		) Engine=Memory`)
+	require.NoError(t, err)
+
+	col8 := column.NewEnum8()
+	col16 := column.NewEnum16()
+	colNullable := column.NewEnum8().Nullable()
+	colArray := column.NewEnum8().Array()
+
+	col8.AppendString("a", "it's, = ok")
+	col8.Append(2)
+	col16.AppendString("large", "small", "large")
+	colNullable.Append(1)
+	colNullable.AppendNil()
+	colNullable.Append(2)
+	colArray.Append([]int8{1, 2}, nil, []int8{2})
+
+	err = conn.Insert(context.Background(), `INSERT INTO test_enum (
+			enum8,
+			enum16,
+			enum8_nullable,
+			enum8_array
+		) VALUES`, col8, col16, colNullable, colArray)
+	require.NoError(t, err)
+
+	selectStmt, err := conn.Select(context.Background(), `SELECT
+		enum8,
+		enum16,
+		enum8_nullable,
+		enum8_array
+	FROM test_enum`)
+	require.NoError(t, err)
+
+	autoColumns := selectStmt.Columns()
+	require.Len(t, autoColumns, 4)
+	col8Read, ok := autoColumns[0].(*column.Enum[int8])
+	require.True(t, ok)
+	col16Read, ok := autoColumns[1].(*column.Enum[int16])
+	require.True(t, ok)
+	colNullableRead, ok := autoColumns[2].(*column.Nullable[int8])
+	require.True(t, ok)
+
+	var col8Data []string
+	var col16Data []int16
+	var col16Labels []string
+	var colNullableData []*int8
+	for selectStmt.Next() {
+		col8Data = col8Read.ReadString(col8Data)
+		col16Data = col16Read.Read(col16Data)
+		col16Labels = append(col16Labels, col16Read.DataString()...)
+		colNullableData = colNullableRead.ReadP(colNullableData)
+	}
+	require.NoError(t, selectStmt.Err())
+
+	assert.Equal(t, []string{"a", "it's, = ok", "b"}, col8Data)
+	assert.Equal(t, []int16{1000, 1, 1000}, col16Data)
+	assert.Equal(t, []string{"large", "small", "large"}, col16Labels)
+	assert.Equal(t, "large", col16Read.Labels()[1000])
+	require.Len(t, colNullableData, 3)
+	assert.Nil(t, colNullableData[1])
+
+	// unknown label
+	col8.Reset()
+	col8.AppendString("c")
+	err = conn.Insert(context.Background(), `INSERT INTO test_enum (enum8) VALUES`, col8)
+	require.EqualError(t, err, `invalid enum label "c" for ClickHouse Type: Enum8('a' = 1, 'b' = 2, 'it\'s, = ok' = -3)`)
+}
@@ -1078,13 +1078,13 @@ func TestInvalidType(t *testing.T) {
 		{
 			name:           "1 byte invalid",
 			columnSelector: "number",
-			wantErr:        "mismatch column type: ClickHouse Type: UInt64, column types: Int8|UInt8|Enum8",
+			wantErr:        "mismatch column type: ClickHouse Type: UInt64, column types: Int8|UInt8|Enum8|Bool",
 			column:         column.New[int8](),
 		},
 		{
 			name:           "2 bytes invalid",
 			columnSelector: "number",
-			wantErr:        "mismatch column type: ClickHouse Type: UInt64, column types: Int16|UInt16|Enum16|Date",
+			wantErr:        "mismatch column type: ClickHouse Type: UInt64, column types: Int16|UInt16|Enum16|Date|BFloat16",
 			column:         column.New[int16](),
 		},
 		{
@@ -1138,7 +1138,7 @@ func TestInvalidType(t *testing.T) {
 		{
 			name:           "invalid nullable inside",
 			columnSelector: "toNullable(number)",
-			wantErr:        "mismatch column type: ClickHouse Type: Nullable(UInt64), column types: Nullable(Int8|UInt8|Enum8)",
+			wantErr:        "mismatch column type: ClickHouse Type: Nullable(UInt64), column types: Nullable(Int8|UInt8|Enum8|Bool)",
 			column:         column.New[int8]().Nullable(),
 		},
 		{
@@ -1150,7 +1150,7 @@ func TestInvalidType(t *testing.T) {
 		{
 			name:           "invalid LowCardinality inside",
 			columnSelector: "toLowCardinality(number)",
-			wantErr:        "mismatch column type: ClickHouse Type: LowCardinality(UInt64), column types: LowCardinality(Int8|UInt8|Enum8)",
+			wantErr:        "mismatch column type: ClickHouse Type: LowCardinality(UInt64), column types: LowCardinality(Int8|UInt8|Enum8|Bool)",
 			column:         column.New[int8]().LC(),
 		},
 		{
@@ -1164,7 +1164,7 @@ func TestInvalidType(t *testing.T) {
 			name:           "invalid nullable LowCardinality inside",
 			columnSelector: "toLowCardinality(toNullable(number))",
 			wantErr: "mismatch column type: ClickHouse Type: LowCardinality(Nullable(UInt64)), column types: " +
-				"LowCardinality(Int8|UInt8|Enum8)",
+				"LowCardinality(Int8|UInt8|Enum8|Bool)",
 
 			column: column.New[int8]().LC(),
 		},
@@ -1177,46 +1177,46 @@ func TestInvalidType(t *testing.T) {
 		{
 			name:           "invalid array inside",
 			columnSelector: "array(number)",
-			wantErr:        "mismatch column type: ClickHouse Type: Array(UInt64), column types: Array(Int8|UInt8|Enum8)",
+			wantErr:        "mismatch column type: ClickHouse Type: Array(UInt64), column types: Array(Int8|UInt8|Enum8|Bool)",
 			column:         column.New[int8]().Array(),
 		},
 		{
 			name:           "invalid array nullable",
 			columnSelector: "array(number)",
-			wantErr:        "mismatch column type: ClickHouse Type: Array(UInt64), column types: Array(Nullable(Int8|UInt8|Enum8))",
+			wantErr:        "mismatch column type: ClickHouse Type: Array(UInt64), column types: Array(Nullable(Int8|UInt8|Enum8|Bool))",
 			column:         column.New[int8]().Nullable().Array(),
 		},
 		{
 			name:           "invalid map",
 			columnSelector: "number",
-			wantErr:        "mismatch column type: ClickHouse Type: UInt64, column types: Map(Int8|UInt8|Enum8, Int8|UInt8|Enum8)",
+			wantErr:        "mismatch column type: ClickHouse Type: UInt64, column types: Map(Int8|UInt8|Enum8|Bool, Int8|UInt8|Enum8|Bool)",
 			column:         column.NewMap[int8, int8](column.New[int8](), column.New[int8]()),
 		},
 		{
 			name:           "invalid map key",
 			columnSelector: "map(number,number)",
-			wantErr:        "mismatch column type: ClickHouse Type: Map(UInt64, UInt64), column types: Map(Int8|UInt8|Enum8, Int8|UInt8|Enum8)",
+			wantErr:        "mismatch column type: ClickHouse Type: Map(UInt64, UInt64), column types: Map(Int8|UInt8|Enum8|Bool, Int8|UInt8|Enum8|Bool)",
 			column:         column.NewMap[int8, int8](column.New[int8](), column.New[int8]()),
 		},
 		{
 			name:           "invalid map value",
 			columnSelector: "map(number,number)",
 			wantErr: "mismatch column type: ClickHouse Type: Map(UInt64, UInt64), column types: " +
-				"Map(Int64|UInt64|Float64|Decimal64|DateTime64, Int8|UInt8|Enum8)",
+				"Map(Int64|UInt64|Float64|Decimal64|DateTime64, Int8|UInt8|Enum8|Bool)",
 			column: column.NewMap[int64, int8](column.New[int64](), column.New[int8]()),
 		},
 		{
 			name:           "invalid tuple",
 			columnSelector: "number",
 			wantErr: "mismatch column type: ClickHouse Type: UInt64, column types: " +
-				"Tuple(Int64|UInt64|Float64|Decimal64|DateTime64,Int8|UInt8|Enum8)",
+				"Tuple(Int64|UInt64|Float64|Decimal64|DateTime64, Int8|UInt8|Enum8|Bool)",
 
 			column: column.NewTuple(column.New[int64](), column.New[int8]()),
 		},
 		{
 			name:           "invalid tuple inside",
 			columnSelector: "tuple(number)",
-			wantErr:        "mismatch column type: ClickHouse Type: Tuple(UInt64), column types: Tuple(Int8|UInt8|Enum8)",
+			wantErr:        "mismatch column type: ClickHouse Type: Tuple(UInt64), column types: Tuple(Int8|UInt8|Enum8|Bool)",
 			column:         column.NewTuple(column.New[int8]()),
 		},
 		{
@@ -1315,7 +1315,7 @@ func TestEnum8InvalidType(t *testing.T) {
 	m := column.New[int16]()
 	m.SetType([]byte("Enum8()"))
 	err := m.Validate()
-	assert.Equal(t, err.Error(), "mismatch column type: ClickHouse Type: Enum8(), column types: Int16|UInt16|Enum16|Date")
+	assert.Equal(t, err.Error(), "mismatch column type: ClickHouse Type: Enum8(), column types: Int16|UInt16|Enum16|Date|BFloat16")
 }
 func TestEnum16InvalidType(t *testing.T) {
 	m := column.New[int32]()
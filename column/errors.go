@@ -15,3 +15,27 @@ func (e ErrInvalidType) Error() string {
 		e.column.ColumnType(),
 	)
 }
+
+// ErrInvalidEnumLabel is returned on insert when an appended label does not exist in the enum type
+type ErrInvalidEnumLabel struct {
+	column ColumnBasic
+	Label  string
+}
+
+func (e ErrInvalidEnumLabel) Error() string {
+	return fmt.Sprintf("invalid enum label %q for ClickHouse Type: %s",
+		e.Label,
+		string(e.column.Type()),
+	)
+}
+
+// ErrUnsupportedAggregateFunction is returned on select when the state layout of the aggregate function is unknown
+type ErrUnsupportedAggregateFunction struct {
+	column ColumnBasic
+}
+
+func (e ErrUnsupportedAggregateFunction) Error() string {
+	return fmt.Sprintf("cannot read the states of ClickHouse Type: %s (unsupported aggregate function state layout)",
+		string(e.column.Type()),
+	)
+}
@@ -0,0 +1,487 @@
+package column
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/vahid-sohrabloo/chconn/v2/internal/helper"
+	"github.com/vahid-sohrabloo/chconn/v2/internal/readerwriter"
+)
+
+const (
+	jsonSerializationV1     = 0
+	jsonSerializationString = 1
+	jsonSerializationV2     = 2
+
+	objectSerializationTuple  = 0
+	objectSerializationString = 1
+)
+
+type jsonPath struct {
+	path   string
+	column ColumnBasic
+}
+
+// JSON is a column of JSON and Object('json') ClickHouse data types.
+//
+// On insert, the values are sent as JSON text and the server parses them.
+//
+// On select, the typed paths, the dynamic paths (Dynamic) and the shared data of the JSON type
+// (or the tuple of the Object('json') type) are read. The inner columns are created by
+// the ColumnByType function (see `SetColumnByType`). The columns that are created by `Select` set it automatically.
+//
+// The values of the dynamic paths that are stored in the shared data
+// (or in the SharedVariant of Dynamic) are returned as the raw binary encoded value.
+type JSON struct {
+	column
+	columnByType ColumnByType
+	numRow       int
+	// version of JSON serialization or the kind of Object('json') serialization
+	version      uint64
+	stringColumn *StringBase[string]
+	objectType   []byte
+	objectColumn ColumnBasic
+	typedType    []byte
+	typedPaths   []jsonPath
+	dynamicPaths []jsonPath
+	dynamicCache map[string]*Dynamic
+	sharedOffset *Base[uint64]
+	sharedPaths  *StringBase[string]
+	sharedValues *StringBase[string]
+}
+
+// NewJSON create a new column of JSON or Object('json') ClickHouse data type
+func NewJSON() *JSON {
+	return &JSON{
+		stringColumn: NewStringBase[string](),
+		dynamicCache: make(map[string]*Dynamic),
+		sharedOffset: New[uint64](),
+		sharedPaths:  NewStringBase[string](),
+		sharedValues: NewStringBase[string](),
+	}
+}
+
+// SetColumnByType set the function to create the inner columns (typed paths and dynamic types).
+func (c *JSON) SetColumnByType(f ColumnByType) *JSON {
+	c.columnByType = f
+	return c
+}
+
+// Data get all the data in current block as a slice of JSON text.
+func (c *JSON) Data() []string {
+	return c.Read(make([]string, 0, c.numRow))
+}
+
+// Read reads all the data in current block as JSON text and append to the input.
+func (c *JSON) Read(value []string) []string {
+	for i := 0; i < c.numRow; i++ {
+		value = append(value, c.Row(i))
+	}
+	return value
+}
+
+// Row return the value of given row as JSON text.
+//
+// NOTE: Row number start from zero
+func (c *JSON) Row(row int) string {
+	if c.isString() {
+		return c.stringColumn.Row(row)
+	}
+	b, err := json.Marshal(jsonValue(c.RowMap(row)))
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// RowMap return the value of given row as a map.
+//
+// The paths are split by dot to the nested maps.
+// For the values that are sent as JSON text, the numbers are returned as json.Number.
+//
+// NOTE: Row number start from zero
+func (c *JSON) RowMap(row int) map[string]any {
+	val := make(map[string]any)
+	switch {
+	case c.isString():
+		d := json.NewDecoder(bytes.NewReader(c.stringColumn.RowBytes(row)))
+		d.UseNumber()
+		//nolint:errcheck
+		d.Decode(&val)
+	case c.objectColumn != nil:
+		if v, ok := objectValue(c.objectColumn, row).(map[string]any); ok {
+			val = v
+		}
+	default:
+		for _, p := range c.typedPaths {
+			setJSONPath(val, p.path, rowAny(p.column, row))
+		}
+		for _, p := range c.dynamicPaths {
+			if v := rowAny(p.column, row); v != nil {
+				setJSONPath(val, p.path, v)
+			}
+		}
+		var start uint64
+		if row > 0 {
+			start = c.sharedOffset.Row(row - 1)
+		}
+		end := c.sharedOffset.Row(row)
+		for i := int(start); i < int(end); i++ {
+			setJSONPath(val, c.sharedPaths.Row(i), c.sharedValues.Row(i))
+		}
+	}
+	return val
+}
+
+// RowAny return the value of given row as a map (see `RowMap`).
+//
+// NOTE: Row number start from zero
+func (c *JSON) RowAny(row int) any {
+	return c.RowMap(row)
+}
+
+// Append JSON text for insert
+func (c *JSON) Append(v ...string) {
+	c.stringColumn.Append(v...)
+	c.numRow += len(v)
+}
+
+// AppendBytes JSON text of bytes for insert
+func (c *JSON) AppendBytes(v ...[]byte) {
+	c.stringColumn.AppendBytes(v...)
+	c.numRow += len(v)
+}
+
+// AppendMap encode the values to JSON text for insert
+func (c *JSON) AppendMap(v ...map[string]any) error {
+	for _, m := range v {
+		b, err := json.Marshal(m)
+		if err != nil {
+			return fmt.Errorf("json: encode value: %w", err)
+		}
+		c.AppendBytes(b)
+	}
+	return nil
+}
+
+// NumRow return number of row for this block
+func (c *JSON) NumRow() int {
+	return c.numRow
+}
+
+// Array return a Array type for this column
+func (c *JSON) Array() *Array[string] {
+	return NewArray[string](c)
+}
+
+// Reset all statuses and buffered data
+//
+// After each reading, the reading data does not need to be reset. It will be automatically reset.
+//
+// When inserting, buffers are reset only after the operation is successful.
+// If an error occurs, you can safely call insert again.
+func (c *JSON) Reset() {
+	c.numRow = 0
+	c.stringColumn.Reset()
+}
+
+// SetWriteBufferSize set write buffer (number of bytes)
+// this buffer only used for writing.
+// By setting this buffer, you will avoid allocating the memory several times.
+func (c *JSON) SetWriteBufferSize(b int) {
+	c.stringColumn.SetWriteBufferSize(b)
+}
+
+func (c *JSON) isString() bool {
+	if helper.IsObject(c.chType) {
+		return c.version == objectSerializationString
+	}
+	return c.version == jsonSerializationString
+}
+
+// ReadRaw read raw data from the reader. it runs automatically
+func (c *JSON) ReadRaw(num int, r *readerwriter.Reader) error {
+	c.r = r
+	c.numRow = num
+	if c.isString() {
+		return c.stringColumn.ReadRaw(num, r)
+	}
+	if c.objectColumn != nil {
+		if err := c.objectColumn.ReadRaw(num, r); err != nil {
+			return fmt.Errorf("json: read object: %w", err)
+		}
+		return nil
+	}
+
+	for _, p := range c.typedPaths {
+		if err := p.column.ReadRaw(num, r); err != nil {
+			return fmt.Errorf("json: read typed path %q: %w", p.path, err)
+		}
+	}
+	for _, p := range c.dynamicPaths {
+		if err := p.column.ReadRaw(num, r); err != nil {
+			return fmt.Errorf("json: read dynamic path %q: %w", p.path, err)
+		}
+	}
+	if err := c.sharedOffset.ReadRaw(num, r); err != nil {
+		return fmt.Errorf("json: read shared data offsets: %w", err)
+	}
+	var total int
+	if num > 0 {
+		total = int(c.sharedOffset.Row(num - 1))
+	}
+	if err := c.sharedPaths.ReadRaw(total, r); err != nil {
+		return fmt.Errorf("json: read shared data paths: %w", err)
+	}
+	if err := c.sharedValues.ReadRaw(total, r); err != nil {
+		return fmt.Errorf("json: read shared data values: %w", err)
+	}
+	return nil
+}
+
+// HeaderReader reads header data from reader
+// it uses internally
+func (c *JSON) HeaderReader(r *readerwriter.Reader, readColumn bool, revision uint64) error {
+	c.r = r
+	err := c.readColumn(readColumn, revision)
+	if err != nil {
+		return err
+	}
+	if helper.IsObject(c.chType) {
+		return c.objectHeaderReader(r, revision)
+	}
+
+	c.version, err = r.Uint64()
+	if err != nil {
+		return fmt.Errorf("json: read serialization version: %w", err)
+	}
+	switch c.version {
+	case jsonSerializationString:
+		return nil
+	case jsonSerializationV1:
+		// max_dynamic_paths
+		if _, err := r.Uvarint(); err != nil {
+			return fmt.Errorf("json: read max dynamic paths: %w", err)
+		}
+	case jsonSerializationV2:
+	default:
+		return fmt.Errorf("json: unsupported serialization version: %d", c.version)
+	}
+
+	numPaths, err := r.Uvarint()
+	if err != nil {
+		return fmt.Errorf("json: read number of dynamic paths: %w", err)
+	}
+	c.dynamicPaths = c.dynamicPaths[:0]
+	for i := uint64(0); i < numPaths; i++ {
+		path, err := r.String()
+		if err != nil {
+			return fmt.Errorf("json: read dynamic path: %w", err)
+		}
+		col, ok := c.dynamicCache[path]
+		if !ok {
+			col = NewDynamic().SetColumnByType(c.columnByType)
+			c.dynamicCache[path] = col
+		}
+		c.dynamicPaths = append(c.dynamicPaths, jsonPath{path: path, column: col})
+	}
+
+	if err := c.createTypedPaths(); err != nil {
+		return err
+	}
+	for _, p := range c.typedPaths {
+		if err := p.column.HeaderReader(r, false, revision); err != nil {
+			return fmt.Errorf("json: read typed path %q header: %w", p.path, err)
+		}
+	}
+	for _, p := range c.dynamicPaths {
+		if err := p.column.HeaderReader(r, false, revision); err != nil {
+			return fmt.Errorf("json: read dynamic path %q header: %w", p.path, err)
+		}
+	}
+	// the shared data (Array(Tuple(String, String))) does not have any header
+	return nil
+}
+
+func (c *JSON) objectHeaderReader(r *readerwriter.Reader, revision uint64) error {
+	kind, err := r.ReadByte()
+	if err != nil {
+		return fmt.Errorf("json: read object serialization kind: %w", err)
+	}
+	c.version = uint64(kind)
+	switch kind {
+	case objectSerializationString:
+		return nil
+	case objectSerializationTuple:
+	default:
+		return fmt.Errorf("json: unsupported object serialization kind: %d", kind)
+	}
+
+	objectType, err := r.ByteString()
+	if err != nil {
+		return fmt.Errorf("json: read object type: %w", err)
+	}
+	if c.objectColumn == nil || !bytes.Equal(objectType, c.objectType) {
+		col, err := c.newColumn(objectType)
+		if err != nil {
+			return err
+		}
+		c.objectType = objectType
+		c.objectColumn = col
+	}
+	return c.objectColumn.HeaderReader(r, false, revision)
+}
+
+// createTypedPaths create the columns of the typed paths of the JSON type. (e.g. JSON(a.b UInt32, c String))
+func (c *JSON) createTypedPaths() error {
+	if bytes.Equal(c.typedType, c.chType) {
+		return nil
+	}
+	c.typedPaths = c.typedPaths[:0]
+	if len(c.chType) > len(helper.JSONParamStr) && bytes.HasPrefix(c.chType, []byte(helper.JSONParamStr)) {
+		params, err := helper.TypesInParentheses(c.chType[len(helper.JSONParamStr) : len(c.chType)-1])
+		if err != nil {
+			return fmt.Errorf("json: invalid type: %w", err)
+		}
+		for _, p := range params {
+			// skip the settings (e.g. max_dynamic_paths=10) and the skipped paths (e.g. SKIP a.b)
+			if len(p.Name) == 0 || string(p.Name) == "SKIP" {
+				continue
+			}
+			col, err := c.newColumn(p.ChType)
+			if err != nil {
+				return err
+			}
+			c.typedPaths = append(c.typedPaths, jsonPath{path: string(p.Name), column: col})
+		}
+		sort.Slice(c.typedPaths, func(i, j int) bool {
+			return c.typedPaths[i].path < c.typedPaths[j].path
+		})
+	}
+	c.typedType = append(c.typedType[:0], c.chType...)
+	return nil
+}
+
+func (c *JSON) newColumn(chType []byte) (ColumnBasic, error) {
+	if c.columnByType == nil {
+		return nil, fmt.Errorf("json: cannot create column for %s: column factory is not set", chType)
+	}
+	col, err := c.columnByType(chType)
+	if err != nil {
+		return nil, fmt.Errorf("json: %w", err)
+	}
+	col.SetType(chType)
+	if err := col.Validate(); err != nil {
+		return nil, fmt.Errorf("json: %w", err)
+	}
+	return col, nil
+}
+
+func (c *JSON) Validate() error {
+	if !helper.IsJSON(c.chType) && !helper.IsObject(c.chType) {
+		return &ErrInvalidType{
+			column: c,
+		}
+	}
+	return nil
+}
+
+func (c *JSON) ColumnType() string {
+	return helper.JSONStr
+}
+
+// WriteTo write data to ClickHouse.
+// it uses internally
+func (c *JSON) WriteTo(w io.Writer) (int64, error) {
+	return c.stringColumn.WriteTo(w)
+}
+
+// HeaderWriter writes header data to writer
+// it uses internally
+func (c *JSON) HeaderWriter(w *readerwriter.Writer) {
+	if helper.IsObject(c.chType) {
+		w.Uint8(objectSerializationString)
+		return
+	}
+	w.Uint64(jsonSerializationString)
+}
+
+func (c *JSON) Elem(arrayLevel int) ColumnBasic {
+	if arrayLevel > 0 {
+		return c.Array().elem(arrayLevel - 1)
+	}
+	return c
+}
+
+// setJSONPath set the value of the path (split by dot) in the nested maps.
+func setJSONPath(m map[string]any, path string, value any) {
+	for {
+		i := strings.IndexByte(path, '.')
+		if i == -1 {
+			break
+		}
+		next, ok := m[path[:i]].(map[string]any)
+		if !ok {
+			if _, exist := m[path[:i]]; exist {
+				// the parent path has a value. keep the full path
+				break
+			}
+			next = make(map[string]any)
+			m[path[:i]] = next
+		}
+		m = next
+		path = path[i+1:]
+	}
+	m[path] = value
+}
+
+// objectValue return the value of the object (Object('json')) as nested maps.
+func objectValue(col ColumnBasic, row int) any {
+	switch c := col.(type) {
+	case *Tuple:
+		val := make(map[string]any, len(c.columns))
+		for _, tupleCol := range c.columns {
+			val[string(tupleCol.Name())] = objectValue(tupleCol, row)
+		}
+		return val
+	case *ArrayBase:
+		var start uint64
+		if row > 0 {
+			start = c.offsetColumn.Row(row - 1)
+		}
+		end := c.offsetColumn.Row(row)
+		val := make([]any, 0, end-start)
+		for i := start; i < end; i++ {
+			val = append(val, objectValue(c.dataColumn, int(i)))
+		}
+		return val
+	}
+	return rowAny(col, row)
+}
+
+// jsonValue convert the values that can not be encoded to JSON (e.g. map[any]any).
+func jsonValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, val := range v {
+			v[k] = jsonValue(val)
+		}
+		return v
+	case map[any]any:
+		val := make(map[string]any, len(v))
+		for k, mapValue := range v {
+			val[fmt.Sprint(k)] = jsonValue(mapValue)
+		}
+		return val
+	case []any:
+		for i, val := range v {
+			v[i] = jsonValue(val)
+		}
+		return v
+	}
+	return v
+}
@@ -0,0 +1,105 @@
+package column_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2"
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+)
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	conn, err := chconn.Connect(context.Background(), connString)
+	require.NoError(t, err)
+
+	err = conn.Exec(context.Background(), `DROP TABLE IF EXISTS test_json`)
+	require.NoError(t, err)
+
+	set := chconn.Settings{
+		{
+			Name:  "allow_experimental_json_type",
+			Value: "true",
+		},
+	}
+	err = conn.ExecWithOption(context.Background(), `CREATE TABLE test_json (
+			id UInt8,
+			json JSON(a.b UInt32, SKIP skipped),
+			json_array Array(JSON)
+		) Engine=Memory`, &chconn.QueryOptions{
+		Settings: set,
+	})
+	require.NoError(t, err)
+
+	colID := column.New[uint8]()
+	colJSON := column.NewJSON()
+	colJSONArray := column.NewJSON().Array()
+
+	colID.Append(1, 2)
+	colJSON.Append(`{"a": {"b": 1}, "c": "str", "skipped": 1}`)
+	require.NoError(t, colJSON.AppendMap(map[string]any{
+		"a": map[string]any{"b": 2},
+		"d": []int{1, 2},
+	}))
+	colJSONArray.Append([]string{`{"x": 1}`, `{"y": "z"}`}, nil)
+
+	err = conn.InsertWithOption(context.Background(), `INSERT INTO test_json (id, json, json_array) VALUES`,
+		&chconn.QueryOptions{Settings: set}, colID, colJSON, colJSONArray)
+	require.NoError(t, err)
+
+	selectStmt, err := conn.Select(context.Background(), `SELECT json, json_array FROM test_json ORDER BY id`)
+	require.NoError(t, err)
+
+	autoColumns := selectStmt.Columns()
+	require.Len(t, autoColumns, 2)
+	colRead, ok := autoColumns[0].(*column.JSON)
+	require.True(t, ok)
+	colArrayRead, ok := autoColumns[1].(*column.Array[string])
+	require.True(t, ok)
+
+	var rows []map[string]any
+	var arrayRows [][]string
+	for selectStmt.Next() {
+		for i := 0; i < colRead.NumRow(); i++ {
+			rows = append(rows, colRead.RowMap(i))
+		}
+		arrayRows = colArrayRead.Read(arrayRows)
+	}
+	require.NoError(t, selectStmt.Err())
+
+	require.Len(t, rows, 2)
+	assert.Equal(t, map[string]any{
+		"a": map[string]any{"b": uint32(1)},
+		"c": "str",
+	}, rows[0])
+	assert.Equal(t, map[string]any{"b": uint32(2)}, rows[1]["a"])
+	assert.Equal(t, []any{int64(1), int64(2)}, rows[1]["d"])
+	require.Len(t, arrayRows, 2)
+	assert.Equal(t, []string{`{"x":1}`, `{"y":"z"}`}, arrayRows[0])
+	assert.Empty(t, arrayRows[1])
+
+	// as string
+	colRead = column.NewJSON()
+	selectStmt, err = conn.SelectWithOption(context.Background(), `SELECT json FROM test_json ORDER BY id`,
+		&chconn.QueryOptions{
+			Settings: chconn.Settings{
+				{
+					Name:  "output_format_native_write_json_as_string",
+					Value: "1",
+				},
+			},
+		}, colRead)
+	require.NoError(t, err)
+	var jsonData []string
+	for selectStmt.Next() {
+		jsonData = colRead.Read(jsonData)
+	}
+	require.NoError(t, selectStmt.Err())
+	assert.Equal(t, []string{`{"a":{"b":1},"c":"str"}`, `{"a":{"b":2},"d":["1","2"]}`}, jsonData)
+}
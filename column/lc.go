@@ -76,6 +76,12 @@ func (c *LowCardinality[T]) Row(row int) T {
 	return c.readedDict[c.readedKeys[row]]
 }
 
+// RowAny return the value of given row as any.
+// NOTE: Row number start from zero
+func (c *LowCardinality[T]) RowAny(row int) any {
+	return c.Row(row)
+}
+
 // Append value for insert
 func (c *LowCardinality[T]) Append(v ...T) {
 	for _, v := range v {
@@ -63,6 +63,15 @@ func (c *LowCardinalityNullable[T]) RowP(row int) *T {
 	return &val
 }
 
+// RowAny return the value of given row as any. it returns nil for the null values.
+// NOTE: Row number start from zero
+func (c *LowCardinalityNullable[T]) RowAny(row int) any {
+	if c.readedKeys[row] == 0 {
+		return nil
+	}
+	return c.readedDict[c.readedKeys[row]]
+}
+
 // Append value for insert
 func (c *LowCardinalityNullable[T]) Append(v ...T) {
 	for _, v := range v {
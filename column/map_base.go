@@ -144,6 +144,21 @@ func (c *MapBase) ValueColumn() ColumnBasic {
 	return c.valueColumn
 }
 
+// RowAny return the value of given row as a map of any.
+// NOTE: Row number start from zero
+func (c *MapBase) RowAny(row int) any {
+	var start uint64
+	if row > 0 {
+		start = c.offsetColumn.Row(row - 1)
+	}
+	end := c.offsetColumn.Row(row)
+	val := make(map[any]any, end-start)
+	for i := start; i < end; i++ {
+		val[rowAny(c.keyColumn, int(i))] = rowAny(c.valueColumn, int(i))
+	}
+	return val
+}
+
 // HeaderReader reads header data from reader
 // it uses internally
 func (c *MapBase) HeaderReader(r *readerwriter.Reader, readColumn bool, revision uint64) error {
@@ -88,6 +88,15 @@ func (c *Nullable[T]) RowP(row int) *T {
 	return &val
 }
 
+// RowAny return the value of given row as any. it returns nil for the null values.
+// NOTE: Row number start from zero
+func (c *Nullable[T]) RowAny(row int) any {
+	if c.b[row] == 1 {
+		return nil
+	}
+	return rowAny(c.dataColumn, row)
+}
+
 // ReadAll read all nils state in this block and append to the input
 func (c *Nullable[T]) ReadNil(value []bool) []bool {
 	return append(value, *(*[]bool)(unsafe.Pointer(&c.b))...)
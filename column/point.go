@@ -2,6 +2,32 @@ package column
 
 import "github.com/vahid-sohrabloo/chconn/v2/types"
 
+// NewPoint create a new column of Point ClickHouse data type
 func NewPoint() *Tuple2[types.Point, float64, float64] {
 	return NewTuple2[types.Point, float64, float64](New[float64](), New[float64]())
 }
+
+// NewRing create a new column of Ring ClickHouse data type
+func NewRing() *Array[types.Point] {
+	return NewPoint().Array()
+}
+
+// NewLineString create a new column of LineString ClickHouse data type
+func NewLineString() *Array[types.Point] {
+	return NewPoint().Array()
+}
+
+// NewPolygon create a new column of Polygon ClickHouse data type
+func NewPolygon() *Array2[types.Point] {
+	return NewPoint().Array().Array()
+}
+
+// NewMultiLineString create a new column of MultiLineString ClickHouse data type
+func NewMultiLineString() *Array2[types.Point] {
+	return NewPoint().Array().Array()
+}
+
+// NewMultiPolygon create a new column of MultiPolygon ClickHouse data type
+func NewMultiPolygon() *Array3[types.Point] {
+	return NewPoint().Array().Array().Array()
+}
@@ -0,0 +1,91 @@
+package column_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2"
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+	"github.com/vahid-sohrabloo/chconn/v2/types"
+)
+
+func TestGeoTypes(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	conn, err := chconn.Connect(context.Background(), connString)
+	require.NoError(t, err)
+
+	err = conn.Exec(context.Background(), `DROP TABLE IF EXISTS test_geo_types`)
+	require.NoError(t, err)
+
+	set := chconn.Settings{
+		{
+			Name:  "allow_experimental_geo_types",
+			Value: "1",
+		},
+	}
+	err = conn.ExecWithOption(context.Background(), `CREATE TABLE test_geo_types (
+			line LineString,
+			multi_line MultiLineString,
+			polygon Polygon
+		) Engine=Memory`, &chconn.QueryOptions{
+		Settings: set,
+	})
+	require.NoError(t, err)
+
+	line := types.LineString{{Col1: 0, Col2: 0}, {Col1: 1.5, Col2: 2}}
+	multiLine := types.MultiLineString{line, {{Col1: 3, Col2: 4}}}
+	polygon := types.Polygon{{{Col1: 0, Col2: 0}, {Col1: 1, Col2: 0}, {Col1: 1, Col2: 1}, {Col1: 0, Col2: 0}}}
+
+	colLine := column.NewLineString()
+	colMultiLine := column.NewMultiLineString()
+	colPolygon := column.NewPolygon()
+	colLine.Append(line)
+	colMultiLine.Append(multiLine)
+	colPolygon.Append(polygon)
+
+	err = conn.Insert(context.Background(), `INSERT INTO test_geo_types (line, multi_line, polygon) VALUES`,
+		colLine,
+		colMultiLine,
+		colPolygon,
+	)
+	require.NoError(t, err)
+
+	selectStmt, err := conn.Select(context.Background(), `SELECT line, multi_line, polygon, wkt(polygon) FROM test_geo_types`)
+	require.NoError(t, err)
+
+	autoColumns := selectStmt.Columns()
+	require.Len(t, autoColumns, 4)
+	colLineRead, ok := autoColumns[0].(*column.Array[types.Point])
+	require.True(t, ok)
+	colMultiLineRead, ok := autoColumns[1].(*column.Array2[types.Point])
+	require.True(t, ok)
+	colPolygonRead, ok := autoColumns[2].(*column.Array2[types.Point])
+	require.True(t, ok)
+	colWKT, ok := autoColumns[3].(*column.String)
+	require.True(t, ok)
+
+	var lineData [][]types.Point
+	var multiLineData, polygonData [][][]types.Point
+	var wktData []string
+	for selectStmt.Next() {
+		lineData = colLineRead.Read(lineData)
+		multiLineData = colMultiLineRead.Read(multiLineData)
+		polygonData = colPolygonRead.Read(polygonData)
+		wktData = colWKT.Read(wktData)
+	}
+	require.NoError(t, selectStmt.Err())
+
+	assert.Equal(t, [][]types.Point{line}, lineData)
+	assert.Equal(t, [][][]types.Point{multiLine}, multiLineData)
+	assert.Equal(t, [][][]types.Point{polygon}, polygonData)
+	require.Len(t, wktData, 1)
+	polygonParsed, err := types.PolygonFromWKT(wktData[0])
+	require.NoError(t, err)
+	assert.Equal(t, types.Polygon(polygonData[0]), polygonParsed)
+}
@@ -80,6 +80,12 @@ func (c *StringBase[T]) Row(row int) T {
 	return T(c.RowBytes(row))
 }
 
+// RowAny return the value of given row as any.
+// NOTE: Row number start from zero
+func (c *StringBase[T]) RowAny(row int) any {
+	return c.Row(row)
+}
+
 // Row return the value of given row.
 //
 // Data is valid only in the current block.
@@ -3,11 +3,15 @@ package column
 import (
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 
 	"github.com/vahid-sohrabloo/chconn/v2/internal/helper"
 	"github.com/vahid-sohrabloo/chconn/v2/internal/readerwriter"
 )
 
+//go:generate go run ./tuples_template/gen.go
+
 // Tuple is a column of Tuple(T1,T2,.....,Tn) ClickHouse data type
 //
 // this is actually a group of columns. it doesn't have any method for read or write data
@@ -98,6 +102,62 @@ func (c *Tuple) Columns() []ColumnBasic {
 	return c.columns
 }
 
+// IsNamed return true if all the elements of the tuple have name. (e.g. Tuple(a Int32, b String))
+//
+// The names are set by the ClickHouse type on validate or by `SetName` of the sub columns.
+func (c *Tuple) IsNamed() bool {
+	for _, col := range c.columns {
+		if len(col.Name()) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Names return the names of the elements of the tuple. the name of unnamed elements is empty.
+func (c *Tuple) Names() []string {
+	names := make([]string, len(c.columns))
+	for i, col := range c.columns {
+		names[i] = string(col.Name())
+	}
+	return names
+}
+
+// ColumnByName return the sub column of the element by name. it returns nil if the element does not exist.
+func (c *Tuple) ColumnByName(name string) ColumnBasic {
+	for _, col := range c.columns {
+		if string(col.Name()) == name {
+			return col
+		}
+	}
+	return nil
+}
+
+// RowMap return the values of given row as a map of the element names.
+// The unnamed elements use the position of the element (starting from one) as the name.
+// NOTE: Row number start from zero
+func (c *Tuple) RowMap(row int) map[string]any {
+	val := make(map[string]any, len(c.columns))
+	for i, col := range c.columns {
+		name := string(col.Name())
+		if name == "" {
+			name = strconv.Itoa(i + 1)
+		}
+		val[name] = rowAny(col, row)
+	}
+	return val
+}
+
+// RowAny return the values of given row as a slice of any (one value for each column).
+// NOTE: Row number start from zero
+func (c *Tuple) RowAny(row int) any {
+	val := make([]any, len(c.columns))
+	for i, col := range c.columns {
+		val[i] = rowAny(col, row)
+	}
+	return val
+}
+
 func (c *Tuple) Validate() error {
 	chType := helper.FilterSimpleAggregate(c.chType)
 	if helper.IsPoint(chType) {
@@ -136,12 +196,20 @@ func (c *Tuple) Validate() error {
 	return nil
 }
 
+// ColumnType return the type of the tuple. for named tuples, the names of the elements are included.
+// (e.g. Tuple(a Int32, b String))
 func (c *Tuple) ColumnType() string {
 	str := helper.TupleStr
-	for _, col := range c.columns {
-		str += col.ColumnType() + ","
+	for i, col := range c.columns {
+		if i > 0 {
+			str += ", "
+		}
+		if name := col.Name(); len(name) > 0 {
+			str += quoteTupleName(name) + " "
+		}
+		str += col.ColumnType()
 	}
-	return str[:len(str)-1] + ")"
+	return str + ")"
 }
 
 // WriteTo write data to ClickHouse.
@@ -172,3 +240,14 @@ func (c *Tuple) Elem(arrayLevel int) ColumnBasic {
 	}
 	return c
 }
+
+// quoteTupleName quote the name of the tuple element with backticks if it is not a simple identifier.
+func quoteTupleName(name []byte) string {
+	for i, b := range name {
+		if b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (i > 0 && b >= '0' && b <= '9') {
+			continue
+		}
+		return "`" + strings.ReplaceAll(string(name), "`", "\\`") + "`"
+	}
+	return string(name)
+}
@@ -56,3 +56,10 @@ func (c *Tuple1[T]) Append(v ...T) {
 func (c *Tuple1[T]) Array() *Array[T] {
 	return NewArray[T](c)
 }
+
+func (c *Tuple1[T]) Elem(arrayLevel int) ColumnBasic {
+	if arrayLevel > 0 {
+		return c.Array().elem(arrayLevel - 1)
+	}
+	return c
+}
@@ -106,3 +106,10 @@ func (c *Tuple2[T, T1, T2]) Append(v ...T) {
 func (c *Tuple2[T, T1, T2]) Array() *Array[T] {
 	return NewArray[T](c)
 }
+
+func (c *Tuple2[T, T1, T2]) Elem(arrayLevel int) ColumnBasic {
+	if arrayLevel > 0 {
+		return c.Array().elem(arrayLevel - 1)
+	}
+	return c
+}
@@ -120,3 +120,10 @@ func (c *Tuple3[T, T1, T2, T3]) Append(v ...T) {
 func (c *Tuple3[T, T1, T2, T3]) Array() *Array[T] {
 	return NewArray[T](c)
 }
+
+func (c *Tuple3[T, T1, T2, T3]) Elem(arrayLevel int) ColumnBasic {
+	if arrayLevel > 0 {
+		return c.Array().elem(arrayLevel - 1)
+	}
+	return c
+}
@@ -134,3 +134,10 @@ func (c *Tuple4[T, T1, T2, T3, T4]) Append(v ...T) {
 func (c *Tuple4[T, T1, T2, T3, T4]) Array() *Array[T] {
 	return NewArray[T](c)
 }
+
+func (c *Tuple4[T, T1, T2, T3, T4]) Elem(arrayLevel int) ColumnBasic {
+	if arrayLevel > 0 {
+		return c.Array().elem(arrayLevel - 1)
+	}
+	return c
+}
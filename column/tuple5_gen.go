@@ -148,3 +148,10 @@ func (c *Tuple5[T, T1, T2, T3, T4, T5]) Append(v ...T) {
 func (c *Tuple5[T, T1, T2, T3, T4, T5]) Array() *Array[T] {
 	return NewArray[T](c)
 }
+
+func (c *Tuple5[T, T1, T2, T3, T4, T5]) Elem(arrayLevel int) ColumnBasic {
+	if arrayLevel > 0 {
+		return c.Array().elem(arrayLevel - 1)
+	}
+	return c
+}
@@ -0,0 +1,185 @@
+package column
+
+import (
+	"unsafe"
+)
+
+type tuple7Value[T1, T2, T3, T4, T5, T6, T7 any] struct {
+	Col1 T1
+	Col2 T2
+	Col3 T3
+	Col4 T4
+	Col5 T5
+	Col6 T6
+	Col7 T7
+}
+
+// Tuple7 is a column of Tuple(T1, T2, T3, T4, T5, T6, T7) ClickHouse data type
+type Tuple7[T ~struct {
+	Col1 T1
+	Col2 T2
+	Col3 T3
+	Col4 T4
+	Col5 T5
+	Col6 T6
+	Col7 T7
+}, T1, T2, T3, T4, T5, T6, T7 any] struct {
+	Tuple
+	col1 Column[T1]
+	col2 Column[T2]
+	col3 Column[T3]
+	col4 Column[T4]
+	col5 Column[T5]
+	col6 Column[T6]
+	col7 Column[T7]
+}
+
+// NewTuple7 create a new tuple of Tuple(T1, T2, T3, T4, T5, T6, T7) ClickHouse data type
+func NewTuple7[T ~struct {
+	Col1 T1
+	Col2 T2
+	Col3 T3
+	Col4 T4
+	Col5 T5
+	Col6 T6
+	Col7 T7
+}, T1, T2, T3, T4, T5, T6, T7 any](
+	column1 Column[T1],
+	column2 Column[T2],
+	column3 Column[T3],
+	column4 Column[T4],
+	column5 Column[T5],
+	column6 Column[T6],
+	column7 Column[T7],
+) *Tuple7[T, T1, T2, T3, T4, T5, T6, T7] {
+	return &Tuple7[T, T1, T2, T3, T4, T5, T6, T7]{
+		Tuple: Tuple{
+			columns: []ColumnBasic{
+				column1,
+				column2,
+				column3,
+				column4,
+				column5,
+				column6,
+				column7,
+			},
+		},
+		col1: column1,
+		col2: column2,
+		col3: column3,
+		col4: column4,
+		col5: column5,
+		col6: column6,
+		col7: column7,
+	}
+}
+
+// NewNested7 create a new nested of Nested(T1, T2, T3, T4, T5, T6, T7) ClickHouse data type
+//
+// this is actually an alias for NewTuple7(T1, T2, T3, T4, T5, T6, T7).Array()
+func NewNested7[T ~struct {
+	Col1 T1
+	Col2 T2
+	Col3 T3
+	Col4 T4
+	Col5 T5
+	Col6 T6
+	Col7 T7
+}, T1, T2, T3, T4, T5, T6, T7 any](
+	column1 Column[T1],
+	column2 Column[T2],
+	column3 Column[T3],
+	column4 Column[T4],
+	column5 Column[T5],
+	column6 Column[T6],
+	column7 Column[T7],
+) *Array[T] {
+	return NewTuple7[T](
+		column1,
+		column2,
+		column3,
+		column4,
+		column5,
+		column6,
+		column7,
+	).Array()
+}
+
+// Data get all the data in current block as a slice.
+func (c *Tuple7[T, T1, T2, T3, T4, T5, T6, T7]) Data() []T {
+	val := make([]T, c.NumRow())
+	for i := 0; i < c.NumRow(); i++ {
+		val[i] = T(tuple7Value[T1, T2, T3, T4, T5, T6, T7]{
+			Col1: c.col1.Row(i),
+			Col2: c.col2.Row(i),
+			Col3: c.col3.Row(i),
+			Col4: c.col4.Row(i),
+			Col5: c.col5.Row(i),
+			Col6: c.col6.Row(i),
+			Col7: c.col7.Row(i),
+		})
+	}
+	return val
+}
+
+// Read reads all the data in current block and append to the input.
+func (c *Tuple7[T, T1, T2, T3, T4, T5, T6, T7]) Read(value []T) []T {
+	valTuple := *(*[]tuple7Value[T1, T2, T3, T4, T5, T6, T7])(unsafe.Pointer(&value))
+	if cap(valTuple)-len(valTuple) >= c.NumRow() {
+		valTuple = valTuple[:len(value)+c.NumRow()]
+	} else {
+		valTuple = append(valTuple, make([]tuple7Value[T1, T2, T3, T4, T5, T6, T7], c.NumRow())...)
+	}
+
+	val := valTuple[len(valTuple)-c.NumRow():]
+	for i := 0; i < c.NumRow(); i++ {
+		val[i].Col1 = c.col1.Row(i)
+		val[i].Col2 = c.col2.Row(i)
+		val[i].Col3 = c.col3.Row(i)
+		val[i].Col4 = c.col4.Row(i)
+		val[i].Col5 = c.col5.Row(i)
+		val[i].Col6 = c.col6.Row(i)
+		val[i].Col7 = c.col7.Row(i)
+	}
+	return *(*[]T)(unsafe.Pointer(&valTuple))
+}
+
+// Row return the value of given row.
+// NOTE: Row number start from zero
+func (c *Tuple7[T, T1, T2, T3, T4, T5, T6, T7]) Row(row int) T {
+	return T(tuple7Value[T1, T2, T3, T4, T5, T6, T7]{
+		Col1: c.col1.Row(row),
+		Col2: c.col2.Row(row),
+		Col3: c.col3.Row(row),
+		Col4: c.col4.Row(row),
+		Col5: c.col5.Row(row),
+		Col6: c.col6.Row(row),
+		Col7: c.col7.Row(row),
+	})
+}
+
+// Append value for insert
+func (c *Tuple7[T, T1, T2, T3, T4, T5, T6, T7]) Append(v ...T) {
+	for _, v := range v {
+		t := tuple7Value[T1, T2, T3, T4, T5, T6, T7](v)
+		c.col1.Append(t.Col1)
+		c.col2.Append(t.Col2)
+		c.col3.Append(t.Col3)
+		c.col4.Append(t.Col4)
+		c.col5.Append(t.Col5)
+		c.col6.Append(t.Col6)
+		c.col7.Append(t.Col7)
+	}
+}
+
+// Array return a Array type for this column
+func (c *Tuple7[T, T1, T2, T3, T4, T5, T6, T7]) Array() *Array[T] {
+	return NewArray[T](c)
+}
+
+func (c *Tuple7[T, T1, T2, T3, T4, T5, T6, T7]) Elem(arrayLevel int) ColumnBasic {
+	if arrayLevel > 0 {
+		return c.Array().elem(arrayLevel - 1)
+	}
+	return c
+}
@@ -0,0 +1,213 @@
+package column
+
+import (
+	"unsafe"
+)
+
+type tuple9Value[T1, T2, T3, T4, T5, T6, T7, T8, T9 any] struct {
+	Col1 T1
+	Col2 T2
+	Col3 T3
+	Col4 T4
+	Col5 T5
+	Col6 T6
+	Col7 T7
+	Col8 T8
+	Col9 T9
+}
+
+// Tuple9 is a column of Tuple(T1, T2, T3, T4, T5, T6, T7, T8, T9) ClickHouse data type
+type Tuple9[T ~struct {
+	Col1 T1
+	Col2 T2
+	Col3 T3
+	Col4 T4
+	Col5 T5
+	Col6 T6
+	Col7 T7
+	Col8 T8
+	Col9 T9
+}, T1, T2, T3, T4, T5, T6, T7, T8, T9 any] struct {
+	Tuple
+	col1 Column[T1]
+	col2 Column[T2]
+	col3 Column[T3]
+	col4 Column[T4]
+	col5 Column[T5]
+	col6 Column[T6]
+	col7 Column[T7]
+	col8 Column[T8]
+	col9 Column[T9]
+}
+
+// NewTuple9 create a new tuple of Tuple(T1, T2, T3, T4, T5, T6, T7, T8, T9) ClickHouse data type
+func NewTuple9[T ~struct {
+	Col1 T1
+	Col2 T2
+	Col3 T3
+	Col4 T4
+	Col5 T5
+	Col6 T6
+	Col7 T7
+	Col8 T8
+	Col9 T9
+}, T1, T2, T3, T4, T5, T6, T7, T8, T9 any](
+	column1 Column[T1],
+	column2 Column[T2],
+	column3 Column[T3],
+	column4 Column[T4],
+	column5 Column[T5],
+	column6 Column[T6],
+	column7 Column[T7],
+	column8 Column[T8],
+	column9 Column[T9],
+) *Tuple9[T, T1, T2, T3, T4, T5, T6, T7, T8, T9] {
+	return &Tuple9[T, T1, T2, T3, T4, T5, T6, T7, T8, T9]{
+		Tuple: Tuple{
+			columns: []ColumnBasic{
+				column1,
+				column2,
+				column3,
+				column4,
+				column5,
+				column6,
+				column7,
+				column8,
+				column9,
+			},
+		},
+		col1: column1,
+		col2: column2,
+		col3: column3,
+		col4: column4,
+		col5: column5,
+		col6: column6,
+		col7: column7,
+		col8: column8,
+		col9: column9,
+	}
+}
+
+// NewNested9 create a new nested of Nested(T1, T2, T3, T4, T5, T6, T7, T8, T9) ClickHouse data type
+//
+// this is actually an alias for NewTuple9(T1, T2, T3, T4, T5, T6, T7, T8, T9).Array()
+func NewNested9[T ~struct {
+	Col1 T1
+	Col2 T2
+	Col3 T3
+	Col4 T4
+	Col5 T5
+	Col6 T6
+	Col7 T7
+	Col8 T8
+	Col9 T9
+}, T1, T2, T3, T4, T5, T6, T7, T8, T9 any](
+	column1 Column[T1],
+	column2 Column[T2],
+	column3 Column[T3],
+	column4 Column[T4],
+	column5 Column[T5],
+	column6 Column[T6],
+	column7 Column[T7],
+	column8 Column[T8],
+	column9 Column[T9],
+) *Array[T] {
+	return NewTuple9[T](
+		column1,
+		column2,
+		column3,
+		column4,
+		column5,
+		column6,
+		column7,
+		column8,
+		column9,
+	).Array()
+}
+
+// Data get all the data in current block as a slice.
+func (c *Tuple9[T, T1, T2, T3, T4, T5, T6, T7, T8, T9]) Data() []T {
+	val := make([]T, c.NumRow())
+	for i := 0; i < c.NumRow(); i++ {
+		val[i] = T(tuple9Value[T1, T2, T3, T4, T5, T6, T7, T8, T9]{
+			Col1: c.col1.Row(i),
+			Col2: c.col2.Row(i),
+			Col3: c.col3.Row(i),
+			Col4: c.col4.Row(i),
+			Col5: c.col5.Row(i),
+			Col6: c.col6.Row(i),
+			Col7: c.col7.Row(i),
+			Col8: c.col8.Row(i),
+			Col9: c.col9.Row(i),
+		})
+	}
+	return val
+}
+
+// Read reads all the data in current block and append to the input.
+func (c *Tuple9[T, T1, T2, T3, T4, T5, T6, T7, T8, T9]) Read(value []T) []T {
+	valTuple := *(*[]tuple9Value[T1, T2, T3, T4, T5, T6, T7, T8, T9])(unsafe.Pointer(&value))
+	if cap(valTuple)-len(valTuple) >= c.NumRow() {
+		valTuple = valTuple[:len(value)+c.NumRow()]
+	} else {
+		valTuple = append(valTuple, make([]tuple9Value[T1, T2, T3, T4, T5, T6, T7, T8, T9], c.NumRow())...)
+	}
+
+	val := valTuple[len(valTuple)-c.NumRow():]
+	for i := 0; i < c.NumRow(); i++ {
+		val[i].Col1 = c.col1.Row(i)
+		val[i].Col2 = c.col2.Row(i)
+		val[i].Col3 = c.col3.Row(i)
+		val[i].Col4 = c.col4.Row(i)
+		val[i].Col5 = c.col5.Row(i)
+		val[i].Col6 = c.col6.Row(i)
+		val[i].Col7 = c.col7.Row(i)
+		val[i].Col8 = c.col8.Row(i)
+		val[i].Col9 = c.col9.Row(i)
+	}
+	return *(*[]T)(unsafe.Pointer(&valTuple))
+}
+
+// Row return the value of given row.
+// NOTE: Row number start from zero
+func (c *Tuple9[T, T1, T2, T3, T4, T5, T6, T7, T8, T9]) Row(row int) T {
+	return T(tuple9Value[T1, T2, T3, T4, T5, T6, T7, T8, T9]{
+		Col1: c.col1.Row(row),
+		Col2: c.col2.Row(row),
+		Col3: c.col3.Row(row),
+		Col4: c.col4.Row(row),
+		Col5: c.col5.Row(row),
+		Col6: c.col6.Row(row),
+		Col7: c.col7.Row(row),
+		Col8: c.col8.Row(row),
+		Col9: c.col9.Row(row),
+	})
+}
+
+// Append value for insert
+func (c *Tuple9[T, T1, T2, T3, T4, T5, T6, T7, T8, T9]) Append(v ...T) {
+	for _, v := range v {
+		t := tuple9Value[T1, T2, T3, T4, T5, T6, T7, T8, T9](v)
+		c.col1.Append(t.Col1)
+		c.col2.Append(t.Col2)
+		c.col3.Append(t.Col3)
+		c.col4.Append(t.Col4)
+		c.col5.Append(t.Col5)
+		c.col6.Append(t.Col6)
+		c.col7.Append(t.Col7)
+		c.col8.Append(t.Col8)
+		c.col9.Append(t.Col9)
+	}
+}
+
+// Array return a Array type for this column
+func (c *Tuple9[T, T1, T2, T3, T4, T5, T6, T7, T8, T9]) Array() *Array[T] {
+	return NewArray[T](c)
+}
+
+func (c *Tuple9[T, T1, T2, T3, T4, T5, T6, T7, T8, T9]) Elem(arrayLevel int) ColumnBasic {
+	if arrayLevel > 0 {
+		return c.Array().elem(arrayLevel - 1)
+	}
+	return c
+}
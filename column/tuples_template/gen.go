@@ -0,0 +1,66 @@
+//go:build ignore
+
+// This program generates the typed tuple columns (tupleN_gen.go) from tuple.go.tmpl
+// and the tupleN.json files. It runs with `go generate` in the column package.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/template"
+)
+
+func main() {
+	tmpl, err := template.New("tuple.go.tmpl").Funcs(template.FuncMap{
+		// iterate return the numbers from start to end (inclusive)
+		"iterate": func(end, start string) ([]int, error) {
+			e, err := strconv.Atoi(end)
+			if err != nil {
+				return nil, err
+			}
+			s, err := strconv.Atoi(start)
+			if err != nil {
+				return nil, err
+			}
+			var numbers []int
+			for i := s; i <= e; i++ {
+				numbers = append(numbers, i)
+			}
+			return numbers, nil
+		},
+	}).ParseFiles("tuples_template/tuple.go.tmpl")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	files, err := filepath.Glob("tuples_template/tuple*.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var data map[string]string
+		if err := json.Unmarshal(b, &data); err != nil {
+			log.Fatalf("%s: %v", file, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			log.Fatalf("%s: %v", file, err)
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			log.Fatalf("%s: %v", file, err)
+		}
+		if err := os.WriteFile("tuple"+data["Numbrer"]+"_gen.go", src, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
@@ -385,3 +385,91 @@ func TestTuples(t *testing.T) {
 	assert.Equal(t, col5Insert, col5ReadData)
 	assert.Equal(t, col5ArrayInsert, col5ArrayReadData)
 }
+
+func TestTupleLargeAndNamed(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	conn, err := chconn.Connect(context.Background(), connString)
+	require.NoError(t, err)
+
+	err = conn.Exec(context.Background(), `DROP TABLE IF EXISTS test_tuple_large_named`)
+	require.NoError(t, err)
+
+	err = conn.Exec(context.Background(), `CREATE TABLE test_tuple_large_named (
+		tuple8 Tuple(Int64, Int64, Int64, Int64, Int64, Int64, Int64, String),
+		named Tuple(a Int32, b String)
+		) Engine=Memory`)
+	require.NoError(t, err)
+
+	type Tuple8 types.Tuple8[int64, int64, int64, int64, int64, int64, int64, string]
+	col8 := column.NewTuple8[Tuple8, int64, int64, int64, int64, int64, int64, int64, string](
+		column.New[int64](),
+		column.New[int64](),
+		column.New[int64](),
+		column.New[int64](),
+		column.New[int64](),
+		column.New[int64](),
+		column.New[int64](),
+		column.NewString(),
+	)
+	colA := column.New[int32]()
+	colB := column.NewString()
+	colNamed := column.NewTuple(colA, colB)
+
+	var col8Insert []Tuple8
+	for i := 0; i < 10; i++ {
+		v := Tuple8{int64(i), 1, 2, 3, 4, 5, 6, fmt.Sprint("str", i)}
+		col8.Append(v)
+		col8Insert = append(col8Insert, v)
+		colA.Append(int32(i))
+		colB.Append(fmt.Sprint("b", i))
+	}
+
+	err = conn.Insert(context.Background(), `INSERT INTO test_tuple_large_named (tuple8, named) VALUES`, col8, colNamed)
+	require.NoError(t, err)
+
+	col8Read := column.NewTuple8[Tuple8, int64, int64, int64, int64, int64, int64, int64, string](
+		column.New[int64](),
+		column.New[int64](),
+		column.New[int64](),
+		column.New[int64](),
+		column.New[int64](),
+		column.New[int64](),
+		column.New[int64](),
+		column.NewString(),
+	)
+	selectStmt, err := conn.Select(context.Background(), `SELECT tuple8 FROM test_tuple_large_named`, col8Read)
+	require.NoError(t, err)
+	var col8Data []Tuple8
+	for selectStmt.Next() {
+		col8Data = col8Read.Read(col8Data)
+	}
+	require.NoError(t, selectStmt.Err())
+	assert.Equal(t, col8Insert, col8Data)
+
+	selectStmt, err = conn.Select(context.Background(), `SELECT named FROM test_tuple_large_named`)
+	require.NoError(t, err)
+	autoColumns := selectStmt.Columns()
+	require.Len(t, autoColumns, 1)
+	colNamedRead, ok := autoColumns[0].(*column.Tuple)
+	require.True(t, ok)
+	var namedData []map[string]any
+	var bData []string
+	for selectStmt.Next() {
+		for i := 0; i < colNamedRead.NumRow(); i++ {
+			namedData = append(namedData, colNamedRead.RowMap(i))
+		}
+		bData = colNamedRead.ColumnByName("b").(*column.String).Read(bData)
+	}
+	require.NoError(t, selectStmt.Err())
+
+	assert.True(t, colNamedRead.IsNamed())
+	assert.Equal(t, []string{"a", "b"}, colNamedRead.Names())
+	assert.Equal(t, "Tuple(a Int32|UInt32|Float32|Decimal32|Date32|DateTime|IPv4, b String)", colNamedRead.ColumnType())
+	assert.Nil(t, colNamedRead.ColumnByName("c"))
+	require.Len(t, namedData, 10)
+	assert.Equal(t, map[string]any{"a": int32(1), "b": "b1"}, namedData[1])
+	assert.Equal(t, "b2", bData[2])
+}
@@ -0,0 +1,276 @@
+package column
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/vahid-sohrabloo/chconn/v2/internal/helper"
+	"github.com/vahid-sohrabloo/chconn/v2/internal/readerwriter"
+)
+
+// VariantNullDiscriminator is the discriminator of the null values in Variant and Dynamic columns
+const VariantNullDiscriminator = 255
+
+const variantDiscriminatorsBasic = 0
+
+// Variant is a column of Variant(T1, T2, ..., Tn) ClickHouse data type
+//
+// The columns must be in the same order as the ClickHouse type (ClickHouse sorts the types by name).
+// Each row has a discriminator (the index of the column) and the value is stored in the column of the discriminator.
+//
+// For insert, append the value to the column of the variant and then call `AppendDiscriminator` with the index of the column.
+type Variant struct {
+	column
+	columns        []ColumnBasic
+	typeNames      []string
+	discriminators []uint8
+	rowIndexes     []int
+	counts         []int
+}
+
+// NewVariant create a new variant of Variant(T1, T2, ..., Tn) ClickHouse data type
+func NewVariant(columns ...ColumnBasic) *Variant {
+	if len(columns) < 1 {
+		panic("variant must have at least one column")
+	}
+	return &Variant{
+		columns: columns,
+	}
+}
+
+// Columns returns the all sub columns
+func (c *Variant) Columns() []ColumnBasic {
+	return c.columns
+}
+
+// Discriminators return the discriminators (the index of the column) of the rows in current block.
+//
+// The discriminator of the null values is VariantNullDiscriminator.
+func (c *Variant) Discriminators() []uint8 {
+	return c.discriminators
+}
+
+// RowIndex return the index of given row in the column of its discriminator.
+//
+// NOTE: Row number start from zero
+func (c *Variant) RowIndex(row int) int {
+	return c.rowIndexes[row]
+}
+
+// RowType return the ClickHouse type of given row. it returns empty string for the null values.
+//
+// NOTE: Row number start from zero
+func (c *Variant) RowType(row int) string {
+	d := c.discriminators[row]
+	if d == VariantNullDiscriminator || int(d) >= len(c.typeNames) {
+		return ""
+	}
+	return c.typeNames[d]
+}
+
+// RowAny return the value of given row as any. it returns nil for the null values.
+//
+// NOTE: Row number start from zero
+func (c *Variant) RowAny(row int) any {
+	d := c.discriminators[row]
+	if d == VariantNullDiscriminator {
+		return nil
+	}
+	return rowAny(c.columns[d], c.rowIndexes[row])
+}
+
+// AppendDiscriminator append the discriminators for insert.
+//
+// The values must be appended to the columns of the discriminators.
+func (c *Variant) AppendDiscriminator(v ...uint8) {
+	c.discriminators = append(c.discriminators, v...)
+}
+
+// AppendNil append null values for insert
+func (c *Variant) AppendNil() {
+	c.discriminators = append(c.discriminators, VariantNullDiscriminator)
+}
+
+// NumRow return number of row for this block
+func (c *Variant) NumRow() int {
+	return len(c.discriminators)
+}
+
+// Array return a Array type for this column
+func (c *Variant) Array() *ArrayBase {
+	return NewArrayBase(c)
+}
+
+// Reset all statuses and buffered data
+//
+// After each reading, the reading data does not need to be reset. It will be automatically reset.
+//
+// When inserting, buffers are reset only after the operation is successful.
+// If an error occurs, you can safely call insert again.
+func (c *Variant) Reset() {
+	c.discriminators = c.discriminators[:0]
+	c.rowIndexes = c.rowIndexes[:0]
+	for _, col := range c.columns {
+		col.Reset()
+	}
+}
+
+// SetWriteBufferSize set write buffer (number of rows)
+// this buffer only used for writing.
+// By setting this buffer, you will avoid allocating the memory several times.
+func (c *Variant) SetWriteBufferSize(row int) {
+	if cap(c.discriminators) < row {
+		c.discriminators = make([]uint8, 0, row)
+	}
+	for _, col := range c.columns {
+		col.SetWriteBufferSize(row)
+	}
+}
+
+// ReadRaw read raw data from the reader. it runs automatically
+func (c *Variant) ReadRaw(num int, r *readerwriter.Reader) error {
+	c.r = r
+	// the discriminators can be grown by the appends without the row indexes
+	if cap(c.discriminators) < num {
+		c.discriminators = make([]uint8, num)
+	} else {
+		c.discriminators = c.discriminators[:num]
+	}
+	if cap(c.rowIndexes) < num {
+		c.rowIndexes = make([]int, num)
+	} else {
+		c.rowIndexes = c.rowIndexes[:num]
+	}
+	if _, err := r.Read(c.discriminators); err != nil {
+		return fmt.Errorf("variant: read discriminators: %w", err)
+	}
+
+	if len(c.counts) != len(c.columns) {
+		c.counts = make([]int, len(c.columns))
+	}
+	for i := range c.counts {
+		c.counts[i] = 0
+	}
+	for i, d := range c.discriminators {
+		if d == VariantNullDiscriminator {
+			continue
+		}
+		if int(d) >= len(c.columns) {
+			return fmt.Errorf("variant: invalid discriminator %d", d)
+		}
+		c.rowIndexes[i] = c.counts[d]
+		c.counts[d]++
+	}
+
+	for i, col := range c.columns {
+		if err := col.ReadRaw(c.counts[i], r); err != nil {
+			return fmt.Errorf("variant: read column index %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// HeaderReader reads header data from reader.
+// it uses internally
+func (c *Variant) HeaderReader(r *readerwriter.Reader, readColumn bool, revision uint64) error {
+	c.r = r
+	err := c.readColumn(readColumn, revision)
+	if err != nil {
+		return err
+	}
+
+	mode, err := r.Uint64()
+	if err != nil {
+		return fmt.Errorf("variant: read discriminators mode: %w", err)
+	}
+	if mode != variantDiscriminatorsBasic {
+		return fmt.Errorf("variant: unsupported discriminators mode: %d", mode)
+	}
+	for i, col := range c.columns {
+		err = col.HeaderReader(r, false, revision)
+		if err != nil {
+			return fmt.Errorf("variant: read column header index %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (c *Variant) Validate() error {
+	chType := helper.FilterSimpleAggregate(c.chType)
+	if !helper.IsVariant(chType) {
+		return &ErrInvalidType{
+			column: c,
+		}
+	}
+
+	columnsVariant, err := helper.TypesInParentheses(chType[helper.LenVariantStr : len(chType)-1])
+	if err != nil {
+		return fmt.Errorf("variant invalid types %w", err)
+	}
+	if len(columnsVariant) != len(c.columns) {
+		//nolint:goerr113
+		return fmt.Errorf("columns number for %s (%s) is not equal to variant columns number: %d != %d",
+			string(c.name),
+			string(c.Type()),
+			len(columnsVariant),
+			len(c.columns),
+		)
+	}
+
+	c.typeNames = c.typeNames[:0]
+	for i, col := range c.columns {
+		col.SetType(columnsVariant[i].ChType)
+		if col.Validate() != nil {
+			return &ErrInvalidType{
+				column: c,
+			}
+		}
+		c.typeNames = append(c.typeNames, string(columnsVariant[i].ChType))
+	}
+	return nil
+}
+
+func (c *Variant) ColumnType() string {
+	str := helper.VariantStr
+	for i, col := range c.columns {
+		if i > 0 {
+			str += ", "
+		}
+		str += col.ColumnType()
+	}
+	return str + ")"
+}
+
+// WriteTo write data to ClickHouse.
+// it uses internally
+func (c *Variant) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(c.discriminators)
+	if err != nil {
+		return int64(n), fmt.Errorf("variant: write discriminators: %w", err)
+	}
+	nw := int64(n)
+	for i, col := range c.columns {
+		n, err := col.WriteTo(w)
+		if err != nil {
+			return nw, fmt.Errorf("variant: write column index %d: %w", i, err)
+		}
+		nw += n
+	}
+	return nw, nil
+}
+
+// HeaderWriter writes header data to writer
+// it uses internally
+func (c *Variant) HeaderWriter(w *readerwriter.Writer) {
+	w.Uint64(variantDiscriminatorsBasic)
+	for _, col := range c.columns {
+		col.HeaderWriter(w)
+	}
+}
+
+func (c *Variant) Elem(arrayLevel int) ColumnBasic {
+	if arrayLevel > 0 {
+		return c.Array().elem(arrayLevel - 1)
+	}
+	return c
+}
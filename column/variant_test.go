@@ -0,0 +1,121 @@
+package column_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2"
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+	"github.com/vahid-sohrabloo/chconn/v2/internal/readerwriter"
+)
+
+func TestVariantAndDynamic(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	conn, err := chconn.Connect(context.Background(), connString)
+	require.NoError(t, err)
+
+	err = conn.Exec(context.Background(), `DROP TABLE IF EXISTS test_variant`)
+	require.NoError(t, err)
+
+	set := chconn.Settings{
+		{
+			Name:  "allow_experimental_variant_type",
+			Value: "true",
+		},
+		{
+			Name:  "allow_experimental_dynamic_type",
+			Value: "true",
+		},
+	}
+	err = conn.ExecWithOption(context.Background(), `CREATE TABLE test_variant (
+			id UInt8,
+			variant Variant(String, UInt64),
+			dynamic Dynamic
+		) Engine=Memory`, &chconn.QueryOptions{
+		Settings: set,
+	})
+	require.NoError(t, err)
+
+	err = conn.ExecWithOption(context.Background(), `INSERT INTO test_variant (id, variant, dynamic)
+		VALUES (1, 'str', 42), (2, NULL, 'dynamic'), (3, 10, [1, 2]), (4, 'x', NULL)`, &chconn.QueryOptions{
+		Settings: set,
+	})
+	require.NoError(t, err)
+
+	colID := column.New[uint8]()
+	colString := column.NewString()
+	colUint := column.New[uint64]()
+	colVariant := column.NewVariant(colString, colUint)
+	colID.Append(5, 6)
+	colUint.Append(20)
+	colVariant.AppendDiscriminator(1)
+	colVariant.AppendNil()
+
+	err = conn.Insert(context.Background(), `INSERT INTO test_variant (id, variant) VALUES`, colID, colVariant)
+	require.NoError(t, err)
+
+	selectStmt, err := conn.Select(context.Background(), `SELECT variant, dynamic FROM test_variant ORDER BY id`)
+	require.NoError(t, err)
+
+	autoColumns := selectStmt.Columns()
+	require.Len(t, autoColumns, 2)
+	colVariantRead, ok := autoColumns[0].(*column.Variant)
+	require.True(t, ok)
+	colDynamicRead, ok := autoColumns[1].(*column.Dynamic)
+	require.True(t, ok)
+
+	var variantData, dynamicData []any
+	var dynamicTypes []string
+	for selectStmt.Next() {
+		for i := 0; i < colVariantRead.NumRow(); i++ {
+			variantData = append(variantData, colVariantRead.RowAny(i))
+			dynamicData = append(dynamicData, colDynamicRead.RowAny(i))
+			dynamicTypes = append(dynamicTypes, colDynamicRead.RowType(i))
+		}
+	}
+	require.NoError(t, selectStmt.Err())
+
+	assert.Equal(t, []any{"str", nil, uint64(10), "x", uint64(20), nil}, variantData)
+	assert.Equal(t, []any{int64(42), "dynamic", []any{int64(1), int64(2)}, nil, nil, nil}, dynamicData)
+	assert.Equal(t, []string{"Int64", "String", "Array(Int64)", "", "", ""}, dynamicTypes)
+}
+
+func TestVariantReadReuse(t *testing.T) {
+	t.Parallel()
+
+	colUint := column.New[uint8]()
+	colString := column.NewString()
+	col := column.NewVariant(colUint, colString)
+	colUint.Append(1)
+	col.AppendDiscriminator(0)
+	col.AppendNil()
+	colString.Append("a")
+	col.AppendDiscriminator(1)
+
+	var buf bytes.Buffer
+	_, err := col.WriteTo(&buf)
+	require.NoError(t, err)
+
+	// the discriminators are grown by SetWriteBufferSize and the appends before the read
+	colRead := column.NewVariant(column.New[uint8](), column.NewString())
+	colRead.SetWriteBufferSize(10)
+	colRead.AppendNil()
+	colRead.Reset()
+	require.NoError(t, colRead.ReadRaw(3, readerwriter.NewReader(&buf)))
+	assert.Equal(t, []uint8{0, column.VariantNullDiscriminator, 1}, colRead.Discriminators())
+	assert.Equal(t, uint8(1), colRead.RowAny(0))
+	assert.Nil(t, colRead.RowAny(1))
+	assert.Equal(t, "a", colRead.RowAny(2))
+	assert.Equal(t, 0, colRead.RowIndex(2))
+
+	colRead.SetType([]byte("UInt8"))
+	var errType *column.ErrInvalidType
+	assert.ErrorAs(t, colRead.Validate(), &errType)
+}
@@ -18,6 +18,7 @@ const defaultUsername = "default"
 const defaultDatabase = "default"
 const defaultDBPort = "9000"
 const defaultClientName = "chx"
+const defaultCancelTimeout = 10 * time.Second
 
 // Method is compression codec.
 type CompressMethod byte
@@ -50,9 +51,10 @@ type Config struct {
 	ClientName        string
 	TLSConfig         *tls.Config // nil disables TLS
 	ConnectTimeout    time.Duration
-	DialFunc          DialFunc   // e.g. net.Dialer.DialContext
-	LookupFunc        LookupFunc // e.g. net.Resolver.LookupHost
-	ReaderFunc        ReaderFunc // e.g. bufio.Reader
+	CancelTimeout     time.Duration // max wait for the server to finish a cancelled query before closing the connection
+	DialFunc          DialFunc      // e.g. net.Dialer.DialContext
+	LookupFunc        LookupFunc    // e.g. net.Resolver.LookupHost
+	ReaderFunc        ReaderFunc    // e.g. bufio.Reader
 	Compress          CompressMethod
 	QuotaKey          string
 	WriterFunc        WriterFunc
@@ -71,6 +73,16 @@ type Config struct {
 	// or prepare statements). If this returns an error the connection attempt fails.
 	AfterConnect AfterConnectFunc
 
+	// StartSpan is called at the start of the Exec, Select and Insert queries to start a client span
+	// (e.g. an OpenTelemetry span). nil disables the client spans.
+	StartSpan StartSpanFunc
+
+	// Observer receives the events of the connections and the queries (e.g. for metrics). nil disables it.
+	Observer Observer
+
+	// Logger logs the queries with their durations and errors. nil disables it.
+	Logger Logger
+
 	createdByParseConfig bool // Used to enforce created by ParseConfig rule.
 
 	// Original connection string that was parsed into config.
@@ -180,6 +192,8 @@ func NetworkAddress(host string, port uint16) (network, address string) {
 //	     in the "checksum" chconn checks the checksum and not use any compress method.
 //		quota_key
 //			the quota key.
+//		cancel_timeout
+//			the maximum seconds to wait for the server to finish a cancelled query. Default 10.
 func ParseConfig(connString string) (*Config, error) {
 	defaultSettings := defaultSettings()
 	envSettings := parseEnvSettings()
@@ -241,6 +255,16 @@ func ParseConfig(connString string) (*Config, error) {
 		config.DialFunc = defaultDialer.DialContext
 	}
 
+	if cancelTimeoutSetting, present := settings["cancel_timeout"]; present {
+		cancelTimeout, err := parseConnectTimeoutSetting(cancelTimeoutSetting)
+		if err != nil {
+			return nil, &parseConfigError{connString: connString, msg: "invalid cancel_timeout", err: err}
+		}
+		config.CancelTimeout = cancelTimeout
+	} else {
+		config.CancelTimeout = defaultCancelTimeout
+	}
+
 	config.LookupFunc = makeDefaultResolver().LookupHost
 
 	notRuntimeParams := map[string]struct{}{
@@ -258,6 +282,7 @@ func ParseConfig(connString string) (*Config, error) {
 		"sslrootcert":          {},
 		"compress":             {},
 		"quota_key":            {},
+		"cancel_timeout":       {},
 	}
 
 	for k, v := range settings {
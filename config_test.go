@@ -799,6 +799,10 @@ func TestParseConfigError(t *testing.T) {
 			name:       "negative connect_timeout",
 			connString: "connect_timeout=-100",
 			err:        "cannot parse `connect_timeout=-100`: invalid connect_timeout (negative timeout)",
+		}, {
+			name:       "invalid cancel_timeout",
+			connString: "cancel_timeout=200g",
+			err:        "cannot parse `cancel_timeout=200g`: invalid cancel_timeout (strconv.ParseInt: parsing \"200g\": invalid syntax)",
 		}, {
 			name:       "negative sslmode",
 			connString: "sslmode=invalid",
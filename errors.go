@@ -39,6 +39,9 @@ var ErrInvalidquoted = errors.New("unterminated quoted string in connection info
 // ErrIPNotFound when can't found ip in connecting
 var ErrIPNotFound = errors.New("ip addr wasn't found")
 
+// ErrPrefetchColumns when columns are passed to a select with prefetch
+var ErrPrefetchColumns = errors.New("columns must not be passed to a select with prefetch")
+
 // ChError represents an error reported by the Clickhouse server
 type ChError struct {
 	Code       ChErrorType
@@ -150,6 +153,14 @@ func newContextAlreadyDoneError(ctx context.Context) (err error) {
 	}
 }
 
+// newCanceledError wraps the exception of a cancelled query in `errTimeout` with the context error.
+func newCanceledError(ctx context.Context, err error) error {
+	return &errTimeout{
+		mainError: err,
+		err:       ctx.Err(),
+	}
+}
+
 type unexpectedPacket struct {
 	expected string
 	actual   interface{}
@@ -319,3 +330,67 @@ type ColumnNotFoundError struct {
 func (e *ColumnNotFoundError) Error() string {
 	return fmt.Sprintf("the input columns do not contain column %q. The column name must be set using the `SetName` method", e.Column)
 }
+
+// ColumnNotSuppliedError represents an error when an insert query omits a column that has no default expression
+// and is not nullable (see QueryOptions.ValidateOmittedColumns)
+type ColumnNotSuppliedError struct {
+	Column string
+}
+
+func (e *ColumnNotSuppliedError) Error() string {
+	return fmt.Sprintf("the insert query does not supply column %q and it has no default expression", e.Column)
+}
+
+type tableColumnsError struct {
+	msg string
+	err error
+}
+
+func (e *tableColumnsError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("table columns: %s", e.msg)
+	}
+	return fmt.Sprintf("table columns: %s (%s)", e.msg, e.err.Error())
+}
+
+func (e *tableColumnsError) Unwrap() error {
+	return e.err
+}
+
+// ColumnScanError represents an error when a column cannot be scanned into a struct field
+type ColumnScanError struct {
+	Column    string
+	ChType    string
+	FieldType string
+	DataType  string
+}
+
+func (e *ColumnScanError) Error() string {
+	if e.DataType == "" {
+		return fmt.Sprintf("cannot scan column %q (%s) into %s: column does not support struct scanning",
+			e.Column, e.ChType, e.FieldType)
+	}
+	return fmt.Sprintf("cannot scan column %q (%s) into %s: column data type is %s",
+		e.Column, e.ChType, e.FieldType, e.DataType)
+}
+
+// ColumnAppendError is returned when a struct field can not be appended to the column
+type ColumnAppendError struct {
+	Column    string
+	ChType    string
+	FieldType string
+}
+
+func (e *ColumnAppendError) Error() string {
+	return fmt.Sprintf("cannot append %s into column %q (%s)", e.FieldType, e.Column, e.ChType)
+}
+
+// FieldNotFoundError is returned when the insert query needs a column without any matching struct field
+type FieldNotFoundError struct {
+	Column string
+	Struct string
+}
+
+func (e *FieldNotFoundError) Error() string {
+	return fmt.Sprintf("struct %s does not have any field for column %q", e.Struct, e.Column)
+}
@@ -16,6 +16,18 @@ type InsertStmt interface {
 	// Close close the statement and release the connection
 	// close will be called automatically after Flush
 	Close()
+	// Columns return the columns of the insert query. they are built from the header that the server sends
+	// (names and ClickHouse types), so it can be used to insert into the tables that are not known at compile time.
+	// the columns are built once and the same columns are returned on each call.
+	Columns() ([]column.ColumnBasic, error)
+	// NewColumns return new columns that are built from the header of the insert query like Columns.
+	// unlike Columns, it returns new columns on each call and it can be called after Flush or Close.
+	NewColumns() ([]column.ColumnBasic, error)
+	// TableColumns return the description of the table columns (default expressions, codecs, ...).
+	// it is nil if the server does not send it (input_format_defaults_for_omitted_fields is disabled)
+	TableColumns() []TableColumn
+	// Stats returns the statistics of the query. they are complete after Flush.
+	Stats() *QueryStats
 }
 
 type insertStmt struct {
@@ -27,23 +39,77 @@ type insertStmt struct {
 	hasError     bool
 	closed       bool
 	finishInsert bool
+	tableColumns []TableColumn
+	columns      []column.ColumnBasic
+	stats        *QueryStats
+	// lastErr is the error of the last Write or Flush
+	lastErr error
+	// endQuery ends the client span and reports the end of the query to the observer on Close
+	endQuery func(error)
 }
 
-func (s *insertStmt) Flush(ctx context.Context) error {
+func (s *insertStmt) Columns() ([]column.ColumnBasic, error) {
+	if s.columns != nil {
+		return s.columns, nil
+	}
+	// use the same type detection as the select statement
+	columns, err := s.conn.getColumnsByChType(s.queryOptions, s.block)
+	if err != nil {
+		return nil, err
+	}
+	s.columns = columns
+	return columns, nil
+}
+
+func (s *insertStmt) NewColumns() ([]column.ColumnBasic, error) {
+	return s.conn.getColumnsByChType(s.queryOptions, s.block)
+}
+
+func (s *insertStmt) TableColumns() []TableColumn {
+	return s.tableColumns
+}
+
+func (s *insertStmt) Stats() *QueryStats {
+	return s.stats
+}
+
+// validateOmittedColumns checks that the columns that are omitted from the insert query have a default expression
+// or are nullable.
+func (s *insertStmt) validateOmittedColumns() error {
+	for i := range s.tableColumns {
+		col := &s.tableColumns[i]
+		if col.canOmit() {
+			continue
+		}
+		if !s.block.hasColumn(col.Name) {
+			return &ColumnNotSuppliedError{
+				Column: col.Name,
+			}
+		}
+	}
+	return nil
+}
+
+func (s *insertStmt) Flush(ctx context.Context) (err error) {
 	defer s.Close()
+	defer func() {
+		s.lastErr = err
+	}()
 	s.finishInsert = true
 
 	if ctx != context.Background() {
 		select {
 		case <-ctx.Done():
-			return newContextAlreadyDoneError(ctx)
+			return s.cancel(ctx)
 		default:
 		}
 		s.conn.contextWatcher.Watch(ctx)
 		defer s.conn.contextWatcher.Unwatch()
 	}
 
-	err := s.conn.sendEmptyBlock()
+	s.conn.writeMu.Lock()
+	err = s.conn.sendEmptyBlock()
+	s.conn.writeMu.Unlock()
 
 	if err != nil {
 		s.hasError = true
@@ -58,6 +124,9 @@ func (s *insertStmt) Flush(ctx context.Context) error {
 		res, err = s.conn.receiveAndProcessData(emptyOnProgress)
 
 		if err != nil {
+			if s.conn.isCancelException(err) {
+				return newCanceledError(ctx, err)
+			}
 			s.hasError = true
 			return err
 		}
@@ -89,6 +158,19 @@ func (s *insertStmt) Flush(ctx context.Context) error {
 	}
 }
 
+// cancel asks the server to stop the insert query and drains the remaining packets. so the connection can be reused.
+func (s *insertStmt) cancel(ctx context.Context) error {
+	s.finishInsert = true
+	if err := s.conn.cancelQuery(); err != nil {
+		s.hasError = true
+		return &InsertError{
+			err:        preferContextOverNetTimeoutError(ctx, err),
+			remoteAddr: s.conn.RawConn().RemoteAddr(),
+		}
+	}
+	return newContextAlreadyDoneError(ctx)
+}
+
 // Close close the statement and release the connection
 // If Next is called and returns false and there are no further blocks,
 // the Rows are closed automatically and it will suffice to check the result of Err.
@@ -102,10 +184,23 @@ func (s *insertStmt) Close() {
 		if s.hasError || !s.finishInsert {
 			s.conn.Close()
 		}
+		if s.endQuery != nil {
+			s.endQuery(s.lastErr)
+		}
 	}
 }
 
 func (s *insertStmt) Write(ctx context.Context, columns ...column.ColumnBasic) error {
+	return s.write(ctx, true, columns...)
+}
+
+// write sends the columns to the server. the columns are reset after they are sent if reset is true.
+func (s *insertStmt) write(ctx context.Context, reset bool, columns ...column.ColumnBasic) (err error) {
+	defer func() {
+		if err != nil {
+			s.lastErr = err
+		}
+	}()
 	if int(s.block.NumColumns) != len(columns) {
 		return &InsertError{
 			err: &ColumnNumberWriteError{
@@ -116,7 +211,6 @@ func (s *insertStmt) Write(ctx context.Context, columns ...column.ColumnBasic) e
 		}
 	}
 
-	var err error
 	if len(columns[0].Name()) != 0 {
 		columns, err = s.block.reorderColumns(columns)
 		if err != nil {
@@ -138,13 +232,16 @@ func (s *insertStmt) Write(ctx context.Context, columns ...column.ColumnBasic) e
 	if ctx != context.Background() {
 		select {
 		case <-ctx.Done():
-			return newContextAlreadyDoneError(ctx)
+			return s.cancel(ctx)
 		default:
 		}
 		s.conn.contextWatcher.Watch(ctx)
 		defer s.conn.contextWatcher.Unwatch()
 	}
 
+	// the Cancel packet must not be written in the middle of the block
+	s.conn.writeMu.Lock()
+	defer s.conn.writeMu.Unlock()
 	err = s.conn.sendData(s.block, columns[0].NumRow())
 	if err != nil {
 		s.hasError = true
@@ -162,8 +259,10 @@ func (s *insertStmt) Write(ctx context.Context, columns ...column.ColumnBasic) e
 			remoteAddr: s.conn.RawConn().RemoteAddr(),
 		}
 	}
-	for _, col := range columns {
-		col.Reset()
+	if reset {
+		for _, col := range columns {
+			col.Reset()
+		}
 	}
 	return nil
 }
@@ -179,19 +278,18 @@ func (ch *conn) InsertWithOption(
 	query string,
 	queryOptions *QueryOptions,
 	columns ...column.ColumnBasic) error {
-	stmt, err := ch.InsertStreamWithOption(ctx, query, queryOptions)
+	stmt, err := ch.insertStream(ctx, query, queryOptions)
 	if err != nil {
 		return err
 	}
 
 	if stmt == nil {
-		ch.reader.SetCompress(false)
-		ch.contextWatcher.Unwatch()
-		ch.unlock()
+		ch.releaseEmptyInsert()
 		return nil
 	}
 	defer stmt.Close()
-	err = stmt.Write(ctx, columns...)
+	// the columns are kept until the insert is flushed. so the insert can be retried if it fails
+	err = stmt.write(ctx, false, columns...)
 	if err != nil {
 		return err
 	}
@@ -205,6 +303,14 @@ func (ch *conn) InsertWithOption(
 	return nil
 }
 
+// releaseEmptyInsert release the connection when the server finish the insert query without asking for data.
+// (e.g. INSERT INTO ... SELECT)
+func (ch *conn) releaseEmptyInsert() {
+	ch.reader.SetCompress(false)
+	ch.contextWatcher.Unwatch()
+	ch.unlock()
+}
+
 func (ch *conn) InsertStream(ctx context.Context, query string) (InsertStmt, error) {
 	return ch.InsertStreamWithOption(ctx, query, nil)
 }
@@ -214,6 +320,31 @@ func (ch *conn) InsertStreamWithOption(
 	ctx context.Context,
 	query string,
 	queryOptions *QueryOptions) (InsertStmt, error) {
+	s, err := ch.insertStream(ctx, query, queryOptions)
+	if err != nil || s == nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (ch *conn) insertStream(
+	ctx context.Context,
+	query string,
+	queryOptions *QueryOptions) (*insertStmt, error) {
+	ctx, endQuery := ch.startQuery(ctx, OperationInsert, query, queryOptions)
+	s, err := ch.insertStreamWithOption(ctx, query, queryOptions)
+	if err != nil || s == nil {
+		endQuery(err)
+		return nil, err
+	}
+	s.endQuery = endQuery
+	return s, nil
+}
+
+func (ch *conn) insertStreamWithOption(
+	ctx context.Context,
+	query string,
+	queryOptions *QueryOptions) (*insertStmt, error) {
 	err := ch.lock()
 	if err != nil {
 		return nil, err
@@ -240,7 +371,7 @@ func (ch *conn) InsertStreamWithOption(
 		queryOptions = emptyQueryOptions
 	}
 
-	err = ch.sendQueryWithOption(query, queryOptions.QueryID, queryOptions.Settings, queryOptions.Parameters)
+	err = ch.sendQueryWithOption(ctx, query, queryOptions)
 	if err != nil {
 		hasError = true
 		return nil, preferContextOverNetTimeoutError(ctx, err)
@@ -250,6 +381,10 @@ func (ch *conn) InsertStreamWithOption(
 		var res interface{}
 		res, err = ch.receiveAndProcessData(emptyOnProgress)
 		if err != nil {
+			if ch.isCancelException(err) {
+				ch.releaseEmptyInsert()
+				return nil, newCanceledError(ctx, err)
+			}
 			hasError = true
 			return nil, preferContextOverNetTimeoutError(ctx, err)
 		}
@@ -295,6 +430,21 @@ func (ch *conn) InsertStreamWithOption(
 		block:        blockData,
 		queryOptions: queryOptions,
 		clientInfo:   nil,
+		tableColumns: ch.tableColumns,
+		stats:        ch.stats,
+	}
+
+	if !queryOptions.ValidateOmittedColumns {
+		return s, nil
+	}
+	if err := s.validateOmittedColumns(); err != nil {
+		// stop the insert query without sending any data
+		if errCancel := ch.cancelQuery(); errCancel != nil {
+			hasError = true
+			return nil, preferContextOverNetTimeoutError(ctx, errCancel)
+		}
+		ch.releaseEmptyInsert()
+		return nil, err
 	}
 
 	return s, nil
@@ -0,0 +1,415 @@
+package chconn
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+)
+
+// InsertStruct insert the rows to the clickhouse server.
+//
+// The columns are created by the ClickHouse types of the insert query (the server header)
+// and the fields of T are matched with the same rules as StructScanner.
+// All columns of the insert query must have a matching field.
+// Tuple columns are filled by the exported fields of the nested struct in order.
+//
+// For pool connections, acquire a connection and use its underlying connection (`Conn()`).
+func InsertStruct[T any](ctx context.Context, c Conn, query string, rows []T) error {
+	return InsertStructWithOption(ctx, c, query, nil, rows)
+}
+
+// InsertStructWithOption insert the rows to the clickhouse server with the query options.
+//
+// See InsertStruct for the details.
+func InsertStructWithOption[T any](
+	ctx context.Context,
+	c Conn,
+	query string,
+	queryOptions *QueryOptions,
+	rows []T,
+) error {
+	var tmpValue T
+	t := reflect.TypeOf(tmpValue)
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("insert struct: %v is not a struct", t)
+	}
+	ch, ok := c.(*conn)
+	if !ok {
+		return fmt.Errorf("insert struct: unsupported connection %T", c)
+	}
+
+	stmt, err := ch.InsertStreamWithOption(ctx, query, queryOptions)
+	if err != nil {
+		return err
+	}
+	if stmt == nil {
+		ch.releaseEmptyInsert()
+		return nil
+	}
+	defer stmt.Close()
+
+	s := stmt.(*insertStmt)
+	fields := make(map[string][]int)
+	structFields(t, nil, fields)
+
+	rowsValue := reflect.ValueOf(rows)
+	columns := make([]column.ColumnBasic, len(s.block.Columns))
+	for i, chCol := range s.block.Columns {
+		index, ok := fields[string(chCol.Name)]
+		if !ok {
+			return &FieldNotFoundError{
+				Column: string(chCol.Name),
+				Struct: t.String(),
+			}
+		}
+		fieldType := t.FieldByIndex(index).Type
+		col, err := ch.insertColumnByType(chCol, fieldType)
+		if err != nil {
+			return err
+		}
+		col.SetWriteBufferSize(len(rows))
+		if err := appendStructField(col, rowsValue, index, fieldType); err != nil {
+			return err
+		}
+		columns[i] = col
+	}
+
+	err = stmt.Write(ctx, columns...)
+	if err != nil {
+		return err
+	}
+	return stmt.Flush(ctx)
+}
+
+// insertColumnByType create the column of the insert header for the struct field.
+//
+// Date and DateTime columns use go time if the field is a time.Time.
+// Time, Time64 and Interval columns use go time if the field is a time.Duration.
+func (ch *conn) insertColumnByType(chCol chColumn, fieldType reflect.Type) (column.ColumnBasic, error) {
+	queryOptions := &QueryOptions{
+		UseGoTime: fieldType != nil && hasTimeType(fieldType),
+	}
+	col, err := ch.columnByType(queryOptions, chCol.ChType, 0, false, false)
+	if err != nil {
+		return nil, err
+	}
+	col.SetName(chCol.Name)
+	col.SetType(chCol.ChType)
+	if err := col.Validate(); err != nil {
+		return nil, err
+	}
+	return col, nil
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+func hasTimeType(t reflect.Type) bool {
+	return hasType(t, timeType, make(map[reflect.Type]bool)) || hasType(t, durationType, make(map[reflect.Type]bool))
+}
+
+func hasType(t, target reflect.Type, seen map[reflect.Type]bool) bool {
+	if t == target {
+		return true
+	}
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return hasType(t.Elem(), target, seen)
+	case reflect.Map:
+		return hasType(t.Key(), target, seen) || hasType(t.Elem(), target, seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasType(t.Field(i).Type, target, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// appendStructField append the field of all the rows to the column.
+//
+// If the column has an `Append` (or `AppendP` for pointer fields) method for the field type,
+// all the values are appended with one call. Otherwise, the values are appended one by one
+// to the sub columns (array, map and tuple).
+func appendStructField(col column.ColumnBasic, rows reflect.Value, index []int, fieldType reflect.Type) error {
+	if method, elemType, ok := appendMethod(col, fieldType, false); ok {
+		values := reflect.MakeSlice(reflect.SliceOf(elemType), rows.Len(), rows.Len())
+		for i := 0; i < rows.Len(); i++ {
+			v := rows.Index(i).FieldByIndex(index)
+			if v.Type() != elemType {
+				v = v.Convert(elemType)
+			}
+			values.Index(i).Set(v)
+		}
+		method.CallSlice([]reflect.Value{values})
+		return nil
+	}
+
+	appendValue, err := newValueAppender(col, fieldType, false)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < rows.Len(); i++ {
+		if err := appendValue(rows.Index(i).FieldByIndex(index)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendMethod find the variadic `Append` (or `AppendP`) method of the column that accepts the field type.
+//
+// If convertNumber is true, any number type can be appended to any number column.
+func appendMethod(
+	col column.ColumnBasic,
+	fieldType reflect.Type,
+	convertNumber bool,
+) (method reflect.Value, elemType reflect.Type, ok bool) {
+	methods := []string{"Append", "AppendP"}
+	if fieldType.Kind() == reflect.Pointer {
+		methods = []string{"AppendP", "Append"}
+	}
+	colValue := reflect.ValueOf(col)
+	for _, name := range methods {
+		method = colValue.MethodByName(name)
+		if !method.IsValid() || !method.Type().IsVariadic() || method.Type().NumIn() != 1 {
+			continue
+		}
+		elemType = method.Type().In(0).Elem()
+		if fieldType == elemType ||
+			(fieldType.Kind() == elemType.Kind() && fieldType.ConvertibleTo(elemType)) ||
+			(convertNumber && isNumberKind(fieldType.Kind()) && isNumberKind(elemType.Kind())) {
+			return method, elemType, true
+		}
+	}
+	return reflect.Value{}, nil, false
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
+
+type arrayAppender interface {
+	AppendLen(v int)
+	Column() column.ColumnBasic
+}
+
+type mapAppender interface {
+	AppendLen(v int)
+	KeyColumn() column.ColumnBasic
+	ValueColumn() column.ColumnBasic
+}
+
+type tupleAppender interface {
+	Columns() []column.ColumnBasic
+}
+
+type nilAppender interface {
+	AppendNil()
+}
+
+// newValueAppender create a function to append one value of the type t to the column.
+//
+// If convertNumber is true, the numbers are converted to the number type of the column.
+// the function returns ColumnAppendError if the number does not fit in the type of the column.
+//
+//nolint:gocyclo
+func newValueAppender(col column.ColumnBasic, t reflect.Type, convertNumber bool) (func(v reflect.Value) error, error) {
+	if method, elemType, ok := appendMethod(col, t, convertNumber); ok {
+		return func(v reflect.Value) error {
+			if v.Kind() != elemType.Kind() {
+				converted, ok := convertNumberValue(v, elemType)
+				if !ok {
+					return &ColumnAppendError{
+						Column:    string(col.Name()),
+						ChType:    string(col.Type()),
+						FieldType: fmt.Sprintf("%s(%v)", v.Type(), v),
+					}
+				}
+				v = converted
+			} else if v.Type() != elemType {
+				v = v.Convert(elemType)
+			}
+			method.Call([]reflect.Value{v})
+			return nil
+		}, nil
+	}
+
+	if t.Kind() == reflect.Pointer {
+		colNil, ok := col.(nilAppender)
+		if !ok {
+			return nil, newColumnAppendError(col, t)
+		}
+		appendElem, err := newValueAppender(col, t.Elem(), convertNumber)
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) error {
+			if v.IsNil() {
+				colNil.AppendNil()
+				return nil
+			}
+			return appendElem(v.Elem())
+		}, nil
+	}
+
+	switch c := col.(type) {
+	case arrayAppender:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return nil, newColumnAppendError(col, t)
+		}
+		appendElem, err := newValueAppender(c.Column(), t.Elem(), convertNumber)
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) error {
+			c.AppendLen(v.Len())
+			for i := 0; i < v.Len(); i++ {
+				if err := appendElem(v.Index(i)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil
+	case mapAppender:
+		if t.Kind() != reflect.Map {
+			return nil, newColumnAppendError(col, t)
+		}
+		appendKey, err := newValueAppender(c.KeyColumn(), t.Key(), convertNumber)
+		if err != nil {
+			return nil, err
+		}
+		appendValue, err := newValueAppender(c.ValueColumn(), t.Elem(), convertNumber)
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) error {
+			c.AppendLen(v.Len())
+			iter := v.MapRange()
+			for iter.Next() {
+				if err := appendKey(iter.Key()); err != nil {
+					return err
+				}
+				if err := appendValue(iter.Value()); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil
+	case tupleAppender:
+		if t.Kind() != reflect.Struct {
+			return nil, newColumnAppendError(col, t)
+		}
+		var fieldIndexes []int
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() && t.Field(i).Tag.Get("ch") != "-" {
+				fieldIndexes = append(fieldIndexes, i)
+			}
+		}
+		if len(fieldIndexes) != len(c.Columns()) {
+			return nil, newColumnAppendError(col, t)
+		}
+		appenders := make([]func(v reflect.Value) error, len(fieldIndexes))
+		for i, tupleCol := range c.Columns() {
+			appendField, err := newValueAppender(tupleCol, t.Field(fieldIndexes[i]).Type, convertNumber)
+			if err != nil {
+				return nil, err
+			}
+			appenders[i] = appendField
+		}
+		return func(v reflect.Value) error {
+			for i, fieldIndex := range fieldIndexes {
+				if err := appenders[i](v.Field(fieldIndex)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil
+	}
+	return nil, newColumnAppendError(col, t)
+}
+
+// convertNumberValue converts the number v to the number type t.
+// It returns false if v does not fit in t or if v is a fractional float and t is an integer type.
+func convertNumberValue(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	res := reflect.New(t).Elem()
+	switch {
+	case v.CanInt():
+		n := v.Int()
+		switch {
+		case res.CanInt():
+			if res.OverflowInt(n) {
+				return res, false
+			}
+			res.SetInt(n)
+		case res.CanUint():
+			if n < 0 || res.OverflowUint(uint64(n)) {
+				return res, false
+			}
+			res.SetUint(uint64(n))
+		default:
+			res.SetFloat(float64(n))
+		}
+	case v.CanUint():
+		n := v.Uint()
+		switch {
+		case res.CanInt():
+			if n > math.MaxInt64 || res.OverflowInt(int64(n)) {
+				return res, false
+			}
+			res.SetInt(int64(n))
+		case res.CanUint():
+			if res.OverflowUint(n) {
+				return res, false
+			}
+			res.SetUint(n)
+		default:
+			res.SetFloat(float64(n))
+		}
+	default:
+		f := v.Float()
+		if res.CanFloat() {
+			if res.OverflowFloat(f) {
+				return res, false
+			}
+			res.SetFloat(f)
+			return res, true
+		}
+		// the fractional, NaN and infinite floats can not be converted to integers
+		if f != math.Trunc(f) {
+			return res, false
+		}
+		switch {
+		case res.CanInt():
+			if f < math.MinInt64 || f >= math.MaxInt64 || res.OverflowInt(int64(f)) {
+				return res, false
+			}
+			res.SetInt(int64(f))
+		default:
+			if f < 0 || f >= math.MaxUint64 || res.OverflowUint(uint64(f)) {
+				return res, false
+			}
+			res.SetUint(uint64(f))
+		}
+	}
+	return res, true
+}
+
+func newColumnAppendError(col column.ColumnBasic, t reflect.Type) error {
+	return &ColumnAppendError{
+		Column:    string(col.Name()),
+		ChType:    string(col.Type()),
+		FieldType: t.String(),
+	}
+}
@@ -0,0 +1,179 @@
+package chconn
+
+import (
+	"context"
+	"math"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+)
+
+type insertStructTuple struct {
+	Name  string
+	Value *int32
+}
+
+type insertStructRow struct {
+	scanTestBase
+	Name     string              `ch:"name"`
+	Nullable *int32              `ch:"nullable"`
+	LC       string              `ch:"lc"`
+	Tags     []string            `ch:"tags"`
+	Attrs    map[string]uint16   `ch:"attrs"`
+	Tuple    insertStructTuple   `ch:"tuple"`
+	Tuples   []insertStructTuple `ch:"tuples"`
+	Date     time.Time           `ch:"date"`
+	Ignored  string              `ch:"-"`
+}
+
+func TestInsertStruct(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	conn, err := Connect(context.Background(), connString)
+	require.NoError(t, err)
+
+	err = conn.Exec(context.Background(), `DROP TABLE IF EXISTS test_insert_struct`)
+	require.NoError(t, err)
+
+	err = conn.Exec(context.Background(), `CREATE TABLE test_insert_struct (
+				id UInt64,
+				name String,
+				nullable Nullable(Int32),
+				lc LowCardinality(String),
+				tags Array(String),
+				attrs Map(String, UInt16),
+				tuple Tuple(String, Nullable(Int32)),
+				tuples Array(Tuple(name String, value Nullable(Int32))),
+				date Date
+			) Engine=Memory`)
+	require.NoError(t, err)
+
+	value := int32(10)
+	date := time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)
+	rows := []insertStructRow{
+		{
+			scanTestBase: scanTestBase{ID: 1},
+			Name:         "first",
+			Nullable:     &value,
+			LC:           "lc",
+			Tags:         []string{"a", "b"},
+			Attrs:        map[string]uint16{"key": 1},
+			Tuple:        insertStructTuple{Name: "tuple", Value: &value},
+			Tuples:       []insertStructTuple{{Name: "t1"}, {Name: "t2", Value: &value}},
+			Date:         date,
+		},
+		{
+			scanTestBase: scanTestBase{ID: 2},
+			Name:         "second",
+			LC:           "lc",
+			Date:         date,
+		},
+	}
+
+	err = InsertStruct(context.Background(), conn, `INSERT INTO test_insert_struct (
+		id, name, nullable, lc, tags, attrs, tuple, tuples, date
+	) VALUES`, rows)
+	require.NoError(t, err)
+
+	stmt, err := conn.Select(context.Background(), `SELECT
+		id, name, nullable, lc, tags, tuple.1 AS tuple_name, length(tuples) AS tuples_len, toString(date) AS date_str
+	FROM test_insert_struct ORDER BY id`)
+	require.NoError(t, err)
+
+	type readRow struct {
+		scanTestBase
+		Name      string   `ch:"name"`
+		Nullable  *int32   `ch:"nullable"`
+		LC        string   `ch:"lc"`
+		Tags      []string `ch:"tags"`
+		TupleName string   `ch:"tuple_name"`
+		TuplesLen uint64   `ch:"tuples_len"`
+		Date      string   `ch:"date_str"`
+	}
+	readRows, err := ScanStructs[readRow](stmt)
+	require.NoError(t, err)
+	require.Len(t, readRows, 2)
+	assert.Equal(t, uint64(1), readRows[0].ID)
+	assert.Equal(t, "first", readRows[0].Name)
+	require.NotNil(t, readRows[0].Nullable)
+	assert.Equal(t, value, *readRows[0].Nullable)
+	assert.Equal(t, []string{"a", "b"}, readRows[0].Tags)
+	assert.Equal(t, "tuple", readRows[0].TupleName)
+	assert.Equal(t, uint64(2), readRows[0].TuplesLen)
+	assert.Equal(t, "2022-01-02", readRows[0].Date)
+	assert.Nil(t, readRows[1].Nullable)
+	assert.Empty(t, readRows[1].Tags)
+	assert.Equal(t, "lc", readRows[1].LC)
+
+	// missing field
+	type missingRow struct {
+		ID uint64 `ch:"id"`
+	}
+	err = InsertStruct(context.Background(), conn, `INSERT INTO test_insert_struct (id, name) VALUES`, []missingRow{{ID: 1}})
+	require.EqualError(t, err, `struct chconn.missingRow does not have any field for column "name"`)
+	assert.True(t, conn.IsClosed())
+
+	conn, err = Connect(context.Background(), connString)
+	require.NoError(t, err)
+	err = InsertStruct(context.Background(), conn, `INSERT INTO test_insert_struct (id) VALUES`, []int{1})
+	require.EqualError(t, err, "insert struct: int is not a struct")
+	conn.Close()
+}
+
+func TestValueAppenderConvertNumber(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		col   column.ColumnBasic
+		value any
+		ok    bool
+	}{
+		{name: "int to uint8", col: column.New[uint8](), value: int64(255), ok: true},
+		{name: "int overflow uint8", col: column.New[uint8](), value: int64(300)},
+		{name: "negative to uint64", col: column.New[uint64](), value: -1},
+		{name: "uint overflow int64", col: column.New[int64](), value: uint64(math.MaxUint64)},
+		{name: "uint to int8", col: column.New[int8](), value: uint(127), ok: true},
+		{name: "int overflow int8", col: column.New[int8](), value: -129},
+		{name: "float to int32", col: column.New[int32](), value: 2.0, ok: true},
+		{name: "fractional float to int32", col: column.New[int32](), value: 1.5},
+		{name: "float overflow int32", col: column.New[int32](), value: float64(math.MaxInt32 + 1)},
+		{name: "negative float to uint32", col: column.New[uint32](), value: float32(-1)},
+		{name: "NaN to int64", col: column.New[int64](), value: math.NaN()},
+		{name: "float overflow float32", col: column.New[float32](), value: math.MaxFloat64},
+		{name: "int to float64", col: column.New[float64](), value: -3, ok: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.col.SetName([]byte("col"))
+			v := reflect.ValueOf(tt.value)
+			appendValue, err := newValueAppender(tt.col, v.Type(), true)
+			require.NoError(t, err)
+			err = appendValue(v)
+			if tt.ok {
+				require.NoError(t, err)
+				assert.Equal(t, 1, tt.col.NumRow())
+				return
+			}
+			var appendErr *ColumnAppendError
+			require.ErrorAs(t, err, &appendErr)
+			assert.Equal(t, "col", appendErr.Column)
+			assert.Equal(t, 0, tt.col.NumRow())
+		})
+	}
+
+	col := column.New[uint8]().Array()
+	appendValue, err := newValueAppender(col, reflect.TypeOf([]int{}), true)
+	require.NoError(t, err)
+	require.NoError(t, appendValue(reflect.ValueOf([]int{1, 2})))
+	require.EqualError(t, appendValue(reflect.ValueOf([]int{1, 256})), `cannot append int(256) into column "" ()`)
+}
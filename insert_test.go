@@ -133,6 +133,98 @@ func TestInsertCtxError(t *testing.T) {
 	assert.True(t, c.IsClosed())
 }
 
+func TestInsertCancel(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	c, err := Connect(context.Background(), connString)
+	require.NoError(t, err)
+
+	err = c.Exec(context.Background(), `DROP TABLE IF EXISTS test_insert_cancel`)
+	require.NoError(t, err)
+	err = c.Exec(context.Background(), `CREATE TABLE test_insert_cancel (
+				int8 Int8
+			) Engine=Memory`)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stmt, err := c.InsertStream(ctx, `INSERT INTO test_insert_cancel (int8) VALUES`)
+	require.NoError(t, err)
+	col := column.New[int8]()
+	col.Append(1, 2, 3)
+	require.NoError(t, stmt.Write(ctx, col))
+	cancel()
+	err = stmt.Flush(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	stmt.Close()
+	assert.False(t, c.IsClosed())
+
+	// the connection is still usable after cancel
+	require.NoError(t, c.Ping(context.Background()))
+	c.Close()
+}
+
+func TestInsertStreamColumns(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	c, err := Connect(context.Background(), connString)
+	require.NoError(t, err)
+
+	err = c.Exec(context.Background(), `DROP TABLE IF EXISTS test_insert_stream_columns`)
+	require.NoError(t, err)
+	err = c.Exec(context.Background(), `CREATE TABLE test_insert_stream_columns (
+				id UInt64,
+				name String,
+				tags Array(String),
+				value Nullable(Int32)
+			) Engine=Memory`)
+	require.NoError(t, err)
+
+	stmt, err := c.InsertStream(context.Background(), `INSERT INTO test_insert_stream_columns VALUES`)
+	require.NoError(t, err)
+	columns, err := stmt.Columns()
+	require.NoError(t, err)
+	require.Len(t, columns, 4)
+	sameColumns, err := stmt.Columns()
+	require.NoError(t, err)
+	assert.Equal(t, columns, sameColumns)
+	assert.Equal(t, "id", string(columns[0].Name()))
+	assert.Equal(t, "Array(String)", string(columns[2].Type()))
+
+	value := int32(5)
+	for _, col := range columns {
+		switch col := col.(type) {
+		case *column.Base[uint64]:
+			col.Append(1, 2)
+		case *column.String:
+			col.Append("a", "b")
+		case *column.Array[string]:
+			col.Append([]string{"x"}, []string{"y", "z"})
+		case *column.Nullable[int32]:
+			col.AppendP(nil, &value)
+		default:
+			t.Fatalf("unexpected column type %T", col)
+		}
+	}
+	require.NoError(t, stmt.Write(context.Background(), columns...))
+	require.NoError(t, stmt.Flush(context.Background()))
+
+	colTags := column.NewString().Array()
+	selectStmt, err := c.Select(context.Background(), `SELECT tags FROM test_insert_stream_columns`, colTags)
+	require.NoError(t, err)
+	var tags [][]string
+	for selectStmt.Next() {
+		tags = colTags.Read(tags)
+	}
+	require.NoError(t, selectStmt.Err())
+	assert.Equal(t, [][]string{{"x"}, {"y", "z"}}, tags)
+	c.Close()
+}
+
 func TestInsertMoreColumnsError(t *testing.T) {
 	t.Parallel()
 
@@ -195,6 +287,44 @@ func TestInsertMoreRowsError(t *testing.T) {
 	assert.True(t, c.IsClosed())
 }
 
+func TestInsertKeepColumnsOnError(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	c, err := Connect(context.Background(), connString)
+	require.NoError(t, err)
+	defer c.Close()
+
+	err = c.Exec(context.Background(), `DROP TABLE IF EXISTS clickhouse_test_insert_keep_columns`)
+	require.NoError(t, err)
+
+	err = c.Exec(context.Background(), `CREATE TABLE clickhouse_test_insert_keep_columns (
+		id UInt64,
+		CONSTRAINT id_check CHECK id < 3
+	) Engine=Memory`)
+	require.NoError(t, err)
+
+	// the server rejects the data when the insert is flushed. the columns are kept for the retry
+	col := column.New[uint64]()
+	col.Append(1, 2, 3)
+	err = c.Insert(context.Background(), `INSERT INTO clickhouse_test_insert_keep_columns (id) VALUES`, col)
+	var chErr *ChError
+	require.ErrorAs(t, err, &chErr)
+	assert.Equal(t, 3, col.NumRow())
+
+	c, err = Connect(context.Background(), connString)
+	require.NoError(t, err)
+	defer c.Close()
+
+	// the columns are reset after the insert is successful
+	col.Reset()
+	col.Append(1, 2)
+	err = c.Insert(context.Background(), `INSERT INTO clickhouse_test_insert_keep_columns (id) VALUES`, col)
+	require.NoError(t, err)
+	assert.Equal(t, 0, col.NumRow())
+}
+
 func TestInsert(t *testing.T) {
 	t.Parallel()
 
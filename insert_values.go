@@ -0,0 +1,105 @@
+package chconn
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+)
+
+// InsertValues insert the rows of values to the clickhouse server.
+//
+// Each row must have one value for each column of the insert query (in the same order).
+// The columns are created by the ClickHouse types of the insert query (the server header).
+// Nil values are inserted as NULL and numbers are converted to the number type of the column.
+func InsertValues(ctx context.Context, c Conn, query string, queryOptions *QueryOptions, rows [][]any) error {
+	ch, ok := c.(*conn)
+	if !ok {
+		return fmt.Errorf("insert values: unsupported connection %T", c)
+	}
+
+	stmt, err := ch.InsertStreamWithOption(ctx, query, queryOptions)
+	if err != nil {
+		return err
+	}
+	if stmt == nil {
+		ch.releaseEmptyInsert()
+		return nil
+	}
+	defer stmt.Close()
+
+	s := stmt.(*insertStmt)
+	for _, row := range rows {
+		if len(row) != int(s.block.NumColumns) {
+			return &InsertError{
+				err: &ColumnNumberWriteError{
+					WriteColumn: len(row),
+					NeedColumn:  s.block.NumColumns,
+				},
+				remoteAddr: ch.RawConn().RemoteAddr(),
+			}
+		}
+	}
+
+	columns := make([]column.ColumnBasic, len(s.block.Columns))
+	for i, chCol := range s.block.Columns {
+		columns[i], err = ch.valuesColumn(chCol, rows, i)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = stmt.Write(ctx, columns...)
+	if err != nil {
+		return err
+	}
+	return stmt.Flush(ctx)
+}
+
+// valuesColumn create the column of the insert header and append the values of the column index.
+//
+// The column is created by the type of the first non-nil value.
+func (ch *conn) valuesColumn(chCol chColumn, rows [][]any, index int) (column.ColumnBasic, error) {
+	var valueType reflect.Type
+	for _, row := range rows {
+		if row[index] != nil {
+			valueType = reflect.TypeOf(row[index])
+			break
+		}
+	}
+	col, err := ch.insertColumnByType(chCol, valueType)
+	if err != nil {
+		return nil, err
+	}
+	col.SetWriteBufferSize(len(rows))
+
+	appenders := make(map[reflect.Type]func(v reflect.Value) error)
+	for _, row := range rows {
+		if row[index] == nil {
+			colNil, ok := col.(nilAppender)
+			if !ok {
+				return nil, &ColumnAppendError{
+					Column:    string(col.Name()),
+					ChType:    string(col.Type()),
+					FieldType: "nil",
+				}
+			}
+			colNil.AppendNil()
+			continue
+		}
+		v := reflect.ValueOf(row[index])
+		appendValue, ok := appenders[v.Type()]
+		if !ok {
+			appendValue, err = newValueAppender(col, v.Type(), true)
+			if err != nil {
+				return nil, err
+			}
+			appenders[v.Type()] = appendValue
+		}
+		if err := appendValue(v); err != nil {
+			return nil, err
+		}
+	}
+	return col, nil
+}
@@ -28,6 +28,14 @@ const (
 
 var RingMainTypeStr = []byte("Array(Tuple(Float64, Float64))")
 
+const LineStringStr = "LineString"
+
+var LineStringMainTypeStr = []byte("Array(Tuple(Float64, Float64))")
+
+const MultiLineStringStr = "MultiLineString"
+
+var MultiLineStringMainTypeStr = []byte("Array(Array(Tuple(Float64, Float64)))")
+
 const (
 	Enum8Str              = "Enum8("
 	Enum8StrLen           = len(Enum8Str)
@@ -37,6 +45,11 @@ const (
 	DateTimeStrLen        = len(DateTimeStr)
 	DateTime64Str         = "DateTime64("
 	DateTime64StrLen      = len(DateTime64Str)
+	TimeStr               = "Time"
+	Time64Str             = "Time64("
+	Time64StrLen          = len(Time64Str)
+	IntervalStr           = "Interval"
+	IntervalStrLen        = len(IntervalStr)
 	DecimalStr            = "Decimal("
 	DecimalStrLen         = len(DecimalStr)
 	FixedStringStr        = "FixedString("
@@ -69,3 +82,23 @@ const (
 const (
 	StringStr = "String"
 )
+
+const (
+	JSONStr      = "JSON"
+	JSONParamStr = "JSON("
+	ObjectStr    = "Object("
+	DynamicStr   = "Dynamic"
+	// SharedVariantStr is the variant of the Dynamic type that keeps the values of the types
+	// that exceed the max_types limit.
+	SharedVariantStr = "SharedVariant"
+)
+
+const (
+	VariantStr    = "Variant("
+	LenVariantStr = len(VariantStr)
+)
+
+const (
+	AggregateFunctionStr    = "AggregateFunction("
+	LenAggregateFunctionStr = len(AggregateFunctionStr)
+)
@@ -10,24 +10,43 @@ func IsEnum8(chType []byte) bool {
 	return len(chType) > Enum8StrLen && (string(chType[:Enum8StrLen]) == Enum8Str)
 }
 
+// ExtractEnum parse the values of Enum8 and Enum16 (the data between the parentheses).
+//
+// For example: 'a' = 1, 'b\'c' = 2
 func ExtractEnum(data []byte) (intToStringMap map[int16]string, stringToIntMap map[string]int16, err error) {
-	enums := bytes.Split(data, []byte(", "))
 	intToStringMap = make(map[int16]string)
 	stringToIntMap = make(map[string]int16)
-	for _, enum := range enums {
-		parts := bytes.SplitN(enum, []byte(" = "), 2)
-		if len(parts) != 2 {
-			return nil, nil, fmt.Errorf("invalid enum: %s", enum)
+	for len(data) > 0 {
+		if data[0] != '\'' {
+			return nil, nil, fmt.Errorf("invalid enum: %s", data)
 		}
-
-		id, err := strconv.ParseInt(string(parts[1]), 10, 8)
+		var label []byte
+		i := 1
+		for ; i < len(data) && data[i] != '\''; i++ {
+			if data[i] == '\\' && i+1 < len(data) {
+				i++
+			}
+			label = append(label, data[i])
+		}
+		if i >= len(data) {
+			return nil, nil, fmt.Errorf("invalid enum: %s", data)
+		}
+		data = data[i+1:]
+		if !bytes.HasPrefix(data, []byte(" = ")) {
+			return nil, nil, fmt.Errorf("invalid enum: %s", data)
+		}
+		data = data[len(" = "):]
+		end := bytes.IndexByte(data, ',')
+		if end == -1 {
+			end = len(data)
+		}
+		id, err := strconv.ParseInt(string(data[:end]), 10, 16)
 		if err != nil {
-			return nil, nil, fmt.Errorf("invalid enum id: %s", parts[1])
+			return nil, nil, fmt.Errorf("invalid enum id: %s", data[:end])
 		}
-
-		val := string(parts[0][1 : len(parts[0])-1])
-		intToStringMap[int16(id)] = val
-		stringToIntMap[val] = int16(id)
+		intToStringMap[int16(id)] = string(label)
+		stringToIntMap[string(label)] = int16(id)
+		data = bytes.TrimLeft(data[end:], ", ")
 	}
 	return intToStringMap, stringToIntMap, nil
 }
@@ -44,6 +63,18 @@ func IsDateTime64(chType []byte) bool {
 	return len(chType) > DateTime64StrLen && (string(chType[:DateTime64StrLen]) == DateTime64Str)
 }
 
+func IsTime(chType []byte) bool {
+	return string(chType) == TimeStr
+}
+
+func IsTime64(chType []byte) bool {
+	return len(chType) > Time64StrLen && (string(chType[:Time64StrLen]) == Time64Str)
+}
+
+func IsInterval(chType []byte) bool {
+	return len(chType) > IntervalStrLen && (string(chType[:IntervalStrLen]) == IntervalStr)
+}
+
 func IsFixedString(chType []byte) bool {
 	return len(chType) > FixedStringStrLen && (string(chType[:FixedStringStrLen]) == FixedStringStr)
 }
@@ -60,6 +91,14 @@ func IsMultiPolygon(chType []byte) bool {
 	return string(chType) == MultiPolygonStr
 }
 
+func IsLineString(chType []byte) bool {
+	return string(chType) == LineStringStr
+}
+
+func IsMultiLineString(chType []byte) bool {
+	return string(chType) == MultiLineStringStr
+}
+
 func IsNested(chType []byte) bool {
 	return len(chType) > LenNestedStr && string(chType[:LenNestedStr]) == NestedStr
 }
@@ -112,30 +151,52 @@ func IsTuple(chType []byte) bool {
 	return len(chType) > LenTupleStr && string(chType[:LenTupleStr]) == TupleStr
 }
 
+func IsJSON(chType []byte) bool {
+	return string(chType) == JSONStr || bytes.HasPrefix(chType, []byte(JSONParamStr))
+}
+
+func IsObject(chType []byte) bool {
+	return len(chType) > len(ObjectStr) && string(chType[:len(ObjectStr)]) == ObjectStr
+}
+
+func IsDynamic(chType []byte) bool {
+	return string(chType) == DynamicStr || bytes.HasPrefix(chType, []byte(DynamicStr+"("))
+}
+
+func IsVariant(chType []byte) bool {
+	return len(chType) > LenVariantStr && string(chType[:LenVariantStr]) == VariantStr
+}
+
+func IsAggregateFunction(chType []byte) bool {
+	return len(chType) > LenAggregateFunctionStr && string(chType[:LenAggregateFunctionStr]) == AggregateFunctionStr
+}
+
 type ColumnData struct {
 	ChType, Name []byte
 }
 
+// TypesInParentheses split the types between the parentheses (e.g. `a String, b Array(Int8)`).
+//
+// The commas inside the parentheses, backticks and single quotes are ignored.
 func TypesInParentheses(b []byte) ([]ColumnData, error) {
 	var columns []ColumnData
 	var openFunc int
-	var hasBacktick bool
+	var quote byte
 	cur := 0
-	for i, char := range b {
-		if char == '`' {
-			if !hasBacktick {
-				hasBacktick = true
-				continue
-			}
-			if b[i-1] != '\\' {
-				hasBacktick = false
+	for i := 0; i < len(b); i++ {
+		char := b[i]
+		if quote != 0 {
+			if char == '\\' {
+				i++
+			} else if char == quote {
+				quote = 0
 			}
 			continue
 		}
-		if hasBacktick {
-			continue
-		}
-		if char == ',' {
+		switch char {
+		case '`', '\'':
+			quote = char
+		case ',':
 			if openFunc == 0 {
 				colData, err := SplitNameType(b[cur:i])
 				if err != nil {
@@ -145,15 +206,10 @@ func TypesInParentheses(b []byte) ([]ColumnData, error) {
 				//  add 2 to skip the ', '
 				cur = i + 2
 			}
-			continue
-		}
-		if char == '(' {
+		case '(':
 			openFunc++
-			continue
-		}
-		if char == ')' {
+		case ')':
 			openFunc--
-			continue
 		}
 	}
 	colData, err := SplitNameType(b[cur:])
@@ -167,10 +223,17 @@ func SplitNameType(b []byte) (ColumnData, error) {
 	// for example: `date f` Array(String)
 	if b[0] == '`' {
 		b = b[1:]
-		for i, char := range b {
-			if char == '`' && b[i-1] != '\\' {
+		for i := 0; i < len(b); i++ {
+			if b[i] == '\\' {
+				i++
+				continue
+			}
+			if b[i] == '`' {
+				if i+2 > len(b) {
+					break
+				}
 				return ColumnData{
-					Name:   b[1 : i+1],
+					Name:   b[:i],
 					ChType: b[i+2:],
 				}, nil
 			}
@@ -178,12 +241,12 @@ func SplitNameType(b []byte) (ColumnData, error) {
 		return ColumnData{}, fmt.Errorf("cannot find closing backtick in %s", b)
 	}
 	for i, char := range b {
-		if char == '(' {
+		if char == '(' || char == '\'' {
 			break
 		}
 		if char == ' ' {
 			return ColumnData{
-				Name:   b[1 : i+1],
+				Name:   b[:i],
 				ChType: b[i+1:],
 			}, nil
 		}
@@ -0,0 +1,64 @@
+package chconn
+
+import (
+	"context"
+	"strconv"
+)
+
+// LogLevel is the level of the logs of Logger.
+type LogLevel int
+
+// The log levels.
+const (
+	LogLevelTrace LogLevel = 6
+	LogLevelDebug LogLevel = 5
+	LogLevelInfo  LogLevel = 4
+	LogLevelWarn  LogLevel = 3
+	LogLevelError LogLevel = 2
+)
+
+func (ll LogLevel) String() string {
+	switch ll {
+	case LogLevelTrace:
+		return "trace"
+	case LogLevelDebug:
+		return "debug"
+	case LogLevelInfo:
+		return "info"
+	case LogLevelWarn:
+		return "warn"
+	case LogLevelError:
+		return "error"
+	}
+	return "invalid level " + strconv.Itoa(int(ll))
+}
+
+// Logger is the interface used to log the queries of the connections.
+// The slogadapter package adapts a log/slog logger.
+type Logger interface {
+	// Log a message at the given level with data key/value pairs. data may be nil.
+	Log(ctx context.Context, level LogLevel, msg string, data map[string]any)
+}
+
+// logQuery logs the end of a query. the failed queries are logged with LogLevelError.
+func (ch *conn) logQuery(ctx context.Context, event *QueryEndEvent) {
+	data := map[string]any{
+		"sql":      event.Query,
+		"duration": event.Duration,
+	}
+	if event.QueryID != "" {
+		data["queryID"] = event.QueryID
+	}
+	if event.RowsSent > 0 {
+		data["rowsSent"] = event.RowsSent
+	}
+	if event.RowsReceived > 0 {
+		data["rowsReceived"] = event.RowsReceived
+	}
+	if event.Err != nil {
+		data["err"] = event.Err
+		ch.config.Logger.Log(ctx, LogLevelError, event.Operation, data)
+		return
+	}
+	ch.config.Logger.Log(ctx, LogLevelInfo, event.Operation, data)
+}
@@ -0,0 +1,163 @@
+package chconn
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// The operations of the queries that are passed to Observer and StartSpanFunc.
+const (
+	OperationExec   = "Exec"
+	OperationSelect = "Select"
+	OperationInsert = "Insert"
+)
+
+// Observer receives the events of the connections and the queries (e.g. to collect metrics).
+// The methods are called synchronously by the connections, so they must be fast and safe for concurrent use.
+type Observer interface {
+	// OnConnect is called after each connection attempt to a host.
+	OnConnect(ctx context.Context, event ConnectEvent)
+	// OnQueryStart is called before a query is sent to the server.
+	OnQueryStart(ctx context.Context, event QueryStartEvent)
+	// OnQueryEnd is called when a query is finished. For select and insert statements it is called on Close.
+	OnQueryEnd(ctx context.Context, event QueryEndEvent)
+}
+
+// ConnectEvent is a connection attempt to a host.
+type ConnectEvent struct {
+	// Address is the address (host:port) of the host.
+	Address string
+	// Duration is the duration of the connection attempt (dial, TLS and hello).
+	Duration time.Duration
+	// Err is the error of the connection attempt. nil on success.
+	Err error
+}
+
+// QueryStartEvent is the start of a query.
+type QueryStartEvent struct {
+	// Operation is OperationExec, OperationSelect or OperationInsert.
+	Operation string
+	Query     string
+	QueryID   string
+}
+
+// QueryEndEvent is the end of a query.
+type QueryEndEvent struct {
+	// Operation is OperationExec, OperationSelect or OperationInsert.
+	Operation string
+	Query     string
+	QueryID   string
+	// Duration is the duration from the start of the query to its end (the Close of the select and insert statements).
+	Duration time.Duration
+	// BytesSent and BytesReceived are the number of the bytes that are written to and read from the connection
+	// during the query (after compression and before TLS).
+	BytesSent     int64
+	BytesReceived int64
+	// BlocksSent and RowsSent are the number of the data blocks and rows that are sent by an insert query.
+	BlocksSent int64
+	RowsSent   int64
+	// BlocksReceived and RowsReceived are the number of the data blocks and rows that are received by a select query.
+	BlocksReceived int64
+	RowsReceived   int64
+	// Err is the error of the query. nil on success.
+	Err error
+}
+
+// ioStat is the cumulative counters of a connection. they are updated atomically because the Cancel packet is written
+// by the context watcher goroutine and the blocks of a select are read by the prefetch goroutine.
+type ioStat struct {
+	bytesSent      int64
+	bytesReceived  int64
+	blocksSent     int64
+	rowsSent       int64
+	blocksReceived int64
+	rowsReceived   int64
+}
+
+func (s *ioStat) load() ioStat {
+	return ioStat{
+		bytesSent:      atomic.LoadInt64(&s.bytesSent),
+		bytesReceived:  atomic.LoadInt64(&s.bytesReceived),
+		blocksSent:     atomic.LoadInt64(&s.blocksSent),
+		rowsSent:       atomic.LoadInt64(&s.rowsSent),
+		blocksReceived: atomic.LoadInt64(&s.blocksReceived),
+		rowsReceived:   atomic.LoadInt64(&s.rowsReceived),
+	}
+}
+
+// countingConn counts the bytes that are read from and written to the connection.
+type countingConn struct {
+	rw   io.ReadWriter
+	stat *ioStat
+}
+
+func (c *countingConn) Read(p []byte) (int, error) {
+	n, err := c.rw.Read(p)
+	atomic.AddInt64(&c.stat.bytesReceived, int64(n))
+	return n, err
+}
+
+func (c *countingConn) Write(p []byte) (int, error) {
+	n, err := c.rw.Write(p)
+	atomic.AddInt64(&c.stat.bytesSent, int64(n))
+	return n, err
+}
+
+// startQuery starts the client span of the query and reports the start of the query to the observer.
+// the end of the query is reported to the observer, the logger and QueryOptions.OnStats.
+// the returned function must be called with the error of the query when the query is finished.
+func (ch *conn) startQuery(
+	ctx context.Context,
+	operation,
+	query string,
+	queryOptions *QueryOptions,
+) (context.Context, func(error)) {
+	ctx, endSpan := ch.startSpan(ctx, operation, query)
+	observer := ch.config.Observer
+	var queryID string
+	var onStats func(*QueryStats)
+	if queryOptions != nil {
+		queryID = queryOptions.QueryID
+		onStats = queryOptions.OnStats
+	}
+	if observer == nil && ch.config.Logger == nil && onStats == nil {
+		return ctx, endSpan
+	}
+	if observer != nil {
+		observer.OnQueryStart(ctx, QueryStartEvent{
+			Operation: operation,
+			Query:     query,
+			QueryID:   queryID,
+		})
+	}
+	start := time.Now()
+	startStat := ch.ioStat.load()
+	return ctx, func(err error) {
+		stat := ch.ioStat.load()
+		event := &QueryEndEvent{
+			Operation:      operation,
+			Query:          query,
+			QueryID:        queryID,
+			Duration:       time.Since(start),
+			BytesSent:      stat.bytesSent - startStat.bytesSent,
+			BytesReceived:  stat.bytesReceived - startStat.bytesReceived,
+			BlocksSent:     stat.blocksSent - startStat.blocksSent,
+			RowsSent:       stat.rowsSent - startStat.rowsSent,
+			BlocksReceived: stat.blocksReceived - startStat.blocksReceived,
+			RowsReceived:   stat.rowsReceived - startStat.rowsReceived,
+			Err:            err,
+		}
+		if observer != nil {
+			observer.OnQueryEnd(ctx, *event)
+		}
+		if ch.config.Logger != nil {
+			ch.logQuery(ctx, event)
+		}
+		if onStats != nil && err == nil {
+			onStats(ch.stats)
+		}
+		endSpan(err)
+	}
+}
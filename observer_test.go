@@ -0,0 +1,102 @@
+package chconn
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+)
+
+type testObserver struct {
+	mu       sync.Mutex
+	connects []ConnectEvent
+	starts   []QueryStartEvent
+	ends     []QueryEndEvent
+}
+
+func (o *testObserver) OnConnect(ctx context.Context, event ConnectEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.connects = append(o.connects, event)
+}
+
+func (o *testObserver) OnQueryStart(ctx context.Context, event QueryStartEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.starts = append(o.starts, event)
+}
+
+func (o *testObserver) OnQueryEnd(ctx context.Context, event QueryEndEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.ends = append(o.ends, event)
+}
+
+func TestObserver(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	config, err := ParseConfig(connString)
+	require.NoError(t, err)
+	observer := &testObserver{}
+	config.Observer = observer
+
+	c, err := ConnectConfig(context.Background(), config)
+	require.NoError(t, err)
+	defer c.Close()
+	require.Len(t, observer.connects, 1)
+	assert.NoError(t, observer.connects[0].Err)
+	assert.Positive(t, observer.connects[0].Duration)
+
+	err = c.Exec(context.Background(), `DROP TABLE IF EXISTS test_observer`)
+	require.NoError(t, err)
+	err = c.Exec(context.Background(), `CREATE TABLE test_observer (id UInt64) Engine=Memory`)
+	require.NoError(t, err)
+
+	col := column.New[uint64]()
+	col.Append(1, 2, 3)
+	err = c.InsertWithOption(context.Background(), `INSERT INTO test_observer (id) VALUES`, &QueryOptions{
+		QueryID: "test_observer_insert",
+	}, col)
+	require.NoError(t, err)
+
+	colRead := column.New[uint64]()
+	stmt, err := c.Select(context.Background(), `SELECT id FROM test_observer`, colRead)
+	require.NoError(t, err)
+	for stmt.Next() {
+	}
+	require.NoError(t, stmt.Err())
+	stmt.Close()
+
+	err = c.Exec(context.Background(), `SELECT * FROM not_found_table`)
+	require.Error(t, err)
+
+	require.Len(t, observer.starts, 5)
+	require.Len(t, observer.ends, 5)
+	assert.Equal(t, QueryStartEvent{
+		Operation: OperationInsert,
+		Query:     `INSERT INTO test_observer (id) VALUES`,
+		QueryID:   "test_observer_insert",
+	}, observer.starts[2])
+
+	insert := observer.ends[2]
+	assert.NoError(t, insert.Err)
+	assert.Equal(t, int64(1), insert.BlocksSent)
+	assert.Equal(t, int64(3), insert.RowsSent)
+	assert.Positive(t, insert.BytesSent)
+	assert.Positive(t, insert.BytesReceived)
+
+	sel := observer.ends[3]
+	assert.Equal(t, OperationSelect, sel.Operation)
+	assert.NoError(t, sel.Err)
+	assert.Equal(t, int64(1), sel.BlocksReceived)
+	assert.Equal(t, int64(3), sel.RowsReceived)
+	assert.Equal(t, int64(0), sel.BlocksSent)
+
+	assert.Equal(t, err, observer.ends[4].Err)
+}
@@ -1,6 +1,9 @@
 package chconn
 
 import (
+	"sort"
+	"time"
+
 	"github.com/vahid-sohrabloo/chconn/v2/column"
 )
 
@@ -28,3 +31,127 @@ func newProfileEvent() *ProfileEvent {
 func (p ProfileEvent) read(c *conn) error {
 	return c.block.readColumnsData(c, true, p.Host, p.Time, p.ThreadID, p.Type, p.Name, p.Value)
 }
+
+// ProfileEventType is the type of a profile event.
+type ProfileEventType int8
+
+const (
+	// ProfileEventIncrement is an event that its value is the increment since the last packet (e.g. SelectedRows).
+	ProfileEventIncrement ProfileEventType = 1
+	// ProfileEventGauge is an event that its value is the current value (e.g. MemoryTrackerUsage).
+	ProfileEventGauge ProfileEventType = 2
+)
+
+// ProfileEventRow is a row of a profile event packet.
+type ProfileEventRow struct {
+	Host     string
+	Time     time.Time
+	ThreadID uint64
+	Type     ProfileEventType
+	Name     string
+	Value    int64
+}
+
+// Rows returns the rows of the profile event packet.
+func (p *ProfileEvent) Rows() []ProfileEventRow {
+	rows := make([]ProfileEventRow, p.Name.NumRow())
+	for i := range rows {
+		rows[i] = ProfileEventRow{
+			Host:     p.Host.Row(i),
+			Time:     time.Unix(int64(p.Time.Row(i)), 0),
+			ThreadID: p.ThreadID.Row(i),
+			Type:     ProfileEventType(p.Type.Row(i)),
+			Name:     p.Name.Row(i),
+			Value:    p.Value.Row(i),
+		}
+	}
+	return rows
+}
+
+// ProfileEvents is the aggregation of the profile events of a query per event name and thread.
+// The increments are summed and the gauges are replaced by their last value.
+// The server sends the rows of the threads and a row with the thread id 0 that is the total of the query.
+type ProfileEvents struct {
+	events map[string]*profileEventValues
+}
+
+type profileEventValues struct {
+	typ      ProfileEventType
+	byThread map[uint64]int64
+}
+
+// NewProfileEvents returns an empty aggregation of profile events.
+func NewProfileEvents() *ProfileEvents {
+	return &ProfileEvents{
+		events: make(map[string]*profileEventValues),
+	}
+}
+
+// Add adds the rows of a profile event packet to the aggregation.
+func (e *ProfileEvents) Add(p *ProfileEvent) {
+	for i := 0; i < p.Name.NumRow(); i++ {
+		name := p.Name.Row(i)
+		v, ok := e.events[name]
+		if !ok {
+			v = &profileEventValues{
+				typ:      ProfileEventType(p.Type.Row(i)),
+				byThread: make(map[uint64]int64),
+			}
+			e.events[name] = v
+		}
+		threadID := p.ThreadID.Row(i)
+		if v.typ == ProfileEventGauge {
+			v.byThread[threadID] = p.Value.Row(i)
+		} else {
+			v.byThread[threadID] += p.Value.Row(i)
+		}
+	}
+}
+
+// Names returns the sorted names of the events.
+func (e *ProfileEvents) Names() []string {
+	names := make([]string, 0, len(e.events))
+	for name := range e.events {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Type returns the type of the event. it is zero if the event is not received.
+func (e *ProfileEvents) Type(name string) ProfileEventType {
+	if v, ok := e.events[name]; ok {
+		return v.typ
+	}
+	return 0
+}
+
+// Get returns the value of the event for the query. it is the value of the thread id 0 (the total of the query)
+// if the server sends it, otherwise the sum of the values of the threads. it is zero if the event is not received.
+func (e *ProfileEvents) Get(name string) int64 {
+	v, ok := e.events[name]
+	if !ok {
+		return 0
+	}
+	if total, ok := v.byThread[0]; ok {
+		return total
+	}
+	var sum int64
+	for _, value := range v.byThread {
+		sum += value
+	}
+	return sum
+}
+
+// ByThread returns the values of the event per thread id. it is nil if the event is not received.
+func (e *ProfileEvents) ByThread(name string) map[uint64]int64 {
+	v, ok := e.events[name]
+	if !ok {
+		return nil
+	}
+	byThread := make(map[uint64]int64, len(v.byThread))
+	for threadID, value := range v.byThread {
+		byThread[threadID] = value
+	}
+	return byThread
+}
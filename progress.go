@@ -44,3 +44,13 @@ func (p *Progress) read(ch *conn) (err error) {
 
 	return nil
 }
+
+// add adds the increments of a progress packet. the server sends the increments since the last packet.
+func (p *Progress) add(o *Progress) {
+	p.ReadRows += o.ReadRows
+	p.ReadBytes += o.ReadBytes
+	p.TotalRows += o.TotalRows
+	p.WriterRows += o.WriterRows
+	p.WrittenBytes += o.WrittenBytes
+	p.ElapsedNS += o.ElapsedNS
+}
@@ -0,0 +1,22 @@
+package chconn
+
+// QueryStats is the statistics of a query that the server sends during the query.
+type QueryStats struct {
+	// Profile is the last profile info of the query. it is nil if the server does not send it (e.g. for insert).
+	Profile *Profile
+	// Progress is the sum of the progress packets of the query.
+	Progress Progress
+	// ProfileEvents is the aggregation of the profile events of the query.
+	ProfileEvents *ProfileEvents
+}
+
+func newQueryStats() *QueryStats {
+	return &QueryStats{
+		ProfileEvents: NewProfileEvents(),
+	}
+}
+
+// MemoryUsage returns the peak memory usage of the query in bytes (the MemoryTrackerPeak gauge of the thread id 0).
+func (s *QueryStats) MemoryUsage() int64 {
+	return s.ProfileEvents.ByThread("MemoryTrackerPeak")[0]
+}
@@ -0,0 +1,123 @@
+package chconn
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+	"github.com/vahid-sohrabloo/chconn/v2/internal/readerwriter"
+)
+
+// readBack writes the appended values of the column and reads them back like the data of the server.
+func readBack(t *testing.T, columns ...column.ColumnBasic) {
+	for _, col := range columns {
+		var buf bytes.Buffer
+		numRow := col.NumRow()
+		_, err := col.WriteTo(&buf)
+		require.NoError(t, err)
+		require.NoError(t, col.ReadRaw(numRow, readerwriter.NewReader(&buf)))
+	}
+}
+
+func TestProfileEvents(t *testing.T) {
+	t.Parallel()
+
+	p := newProfileEvent()
+	// the server sends the rows of the threads and a row of the thread id 0 with the total of the query
+	p.Host.Append("host", "host", "host", "host", "host", "host")
+	p.Time.Append(1, 1, 1, 1, 1, 1)
+	p.ThreadID.Append(1, 2, 0, 1, 0, 1)
+	p.Type.Append(int8(ProfileEventIncrement), int8(ProfileEventIncrement), int8(ProfileEventIncrement),
+		int8(ProfileEventGauge), int8(ProfileEventGauge), int8(ProfileEventIncrement))
+	p.Name.Append("SelectedRows", "SelectedRows", "SelectedRows",
+		"MemoryTrackerPeak", "MemoryTrackerPeak", "SelectedBytes")
+	p.Value.Append(10, 20, 30, 400, 1000, 100)
+	readBack(t, p.Host, p.Time, p.ThreadID, p.Type, p.Name, p.Value)
+
+	rows := p.Rows()
+	require.Len(t, rows, 6)
+	assert.Equal(t, ProfileEventRow{
+		Host:     "host",
+		Time:     rows[4].Time,
+		ThreadID: 0,
+		Type:     ProfileEventGauge,
+		Name:     "MemoryTrackerPeak",
+		Value:    1000,
+	}, rows[4])
+	assert.Equal(t, int64(1), rows[4].Time.Unix())
+
+	events := NewProfileEvents()
+	events.Add(p)
+	events.Add(p)
+	assert.Equal(t, []string{"MemoryTrackerPeak", "SelectedBytes", "SelectedRows"}, events.Names())
+	// the increments are summed and the gauges are replaced
+	assert.Equal(t, map[uint64]int64{0: 60, 1: 20, 2: 40}, events.ByThread("SelectedRows"))
+	// the total of the thread id 0 is used and the threads are not counted again
+	assert.Equal(t, int64(60), events.Get("SelectedRows"))
+	// the sum of the threads is used without the thread id 0
+	assert.Equal(t, int64(200), events.Get("SelectedBytes"))
+	assert.Equal(t, map[uint64]int64{0: 1000, 1: 400}, events.ByThread("MemoryTrackerPeak"))
+	assert.Equal(t, int64(1000), events.Get("MemoryTrackerPeak"))
+	assert.Equal(t, ProfileEventGauge, events.Type("MemoryTrackerPeak"))
+	assert.Equal(t, int64(0), events.Get("NotFound"))
+	assert.Nil(t, events.ByThread("NotFound"))
+	assert.Equal(t, ProfileEventType(0), events.Type("NotFound"))
+
+	stats := newQueryStats()
+	stats.ProfileEvents = events
+	assert.Equal(t, int64(1000), stats.MemoryUsage())
+
+	var progress Progress
+	progress.add(&Progress{ReadRows: 1, ReadBytes: 8, ElapsedNS: 10})
+	progress.add(&Progress{ReadRows: 2, ReadBytes: 16, ElapsedNS: 5})
+	assert.Equal(t, Progress{ReadRows: 3, ReadBytes: 24, ElapsedNS: 15}, progress)
+}
+
+func TestQueryStats(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	c, err := Connect(context.Background(), connString)
+	require.NoError(t, err)
+	defer c.Close()
+
+	col := column.New[uint64]()
+	stmt, err := c.Select(context.Background(), "SELECT number FROM system.numbers LIMIT 1000", col)
+	require.NoError(t, err)
+	for stmt.Next() {
+	}
+	require.NoError(t, stmt.Err())
+	stmt.Close()
+
+	stats := stmt.Stats()
+	require.NotNil(t, stats)
+	require.NotNil(t, stats.Profile)
+	assert.Equal(t, uint64(1000), stats.Profile.Rows)
+	assert.Equal(t, uint64(1000), stats.Progress.ReadRows)
+	assert.Equal(t, uint64(8000), stats.Progress.ReadBytes)
+	assert.Equal(t, int64(1000), stats.ProfileEvents.Get("SelectedRows"))
+	assert.Positive(t, stats.MemoryUsage())
+
+	err = c.Exec(context.Background(), `DROP TABLE IF EXISTS test_query_stats`)
+	require.NoError(t, err)
+	err = c.Exec(context.Background(), `CREATE TABLE test_query_stats (id UInt64) Engine=Memory`)
+	require.NoError(t, err)
+
+	var insertStats *QueryStats
+	colInsert := column.New[uint64]()
+	colInsert.Append(1, 2, 3)
+	err = c.InsertWithOption(context.Background(), `INSERT INTO test_query_stats (id) VALUES`, &QueryOptions{
+		OnStats: func(s *QueryStats) {
+			insertStats = s
+		},
+	}, colInsert)
+	require.NoError(t, err)
+	require.NotNil(t, insertStats)
+	assert.Equal(t, uint64(3), insertStats.Progress.WriterRows)
+	assert.Equal(t, int64(3), insertStats.ProfileEvents.Get("InsertedRows"))
+}
@@ -0,0 +1,208 @@
+package chconn
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+)
+
+// StructScanner maps the columns of a select statement to the fields of the struct T.
+//
+// Columns are matched with the `ch:"name"` tag of the fields. Fields without a tag use the field name
+// and fields with the `ch:"-"` tag are ignored. Columns without any matching field are skipped.
+//
+// The scanner uses the columns of the select statement, so you can select without passing any column
+// and the columns are created automatically by the ClickHouse types. The column buffers are reused across blocks.
+type StructScanner[T any] struct {
+	fields   map[string][]int
+	columns  []column.ColumnBasic
+	mappings []scanMapping
+	err      error
+}
+
+type scanMapping struct {
+	fieldIndex []int
+	fieldType  reflect.Type
+	data       reflect.Value
+	convert    bool
+}
+
+// NewStructScanner create a new struct scanner for T.
+//
+// T must be a struct.
+func NewStructScanner[T any]() *StructScanner[T] {
+	s := &StructScanner[T]{
+		fields: make(map[string][]int),
+	}
+	var tmpValue T
+	t := reflect.TypeOf(tmpValue)
+	if t == nil || t.Kind() != reflect.Struct {
+		s.err = fmt.Errorf("struct scanner: %v is not a struct", t)
+		return s
+	}
+	structFields(t, nil, s.fields)
+	return s
+}
+
+// structFields collect the fields of the struct by the `ch` tag (or the field name).
+//
+// Embedded structs without a tag are flattened.
+func structFields(t reflect.Type, parentIndex []int, fields map[string][]int) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, hasTag := f.Tag.Lookup("ch")
+		if tag == "-" {
+			continue
+		}
+		index := make([]int, len(parentIndex), len(parentIndex)+1)
+		copy(index, parentIndex)
+		index = append(index, i)
+		if f.Anonymous && !hasTag && f.Type.Kind() == reflect.Struct {
+			structFields(f.Type, index, fields)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if hasTag && tag != "" {
+			name = tag
+		}
+		if _, ok := fields[name]; !ok {
+			fields[name] = index
+		}
+	}
+}
+
+// Read reads all the rows of the current block and append them to the input.
+//
+// It should be called after each successful `Next()` of the select statement.
+func (s *StructScanner[T]) Read(stmt SelectStmt, value []T) ([]T, error) {
+	if s.err != nil {
+		return value, s.err
+	}
+	columns := stmt.Columns()
+	if !s.sameColumns(columns) {
+		if err := s.prepare(columns); err != nil {
+			return value, err
+		}
+	}
+	numRows := stmt.RowsInBlock()
+	start := len(value)
+	if cap(value)-len(value) >= numRows {
+		value = value[:len(value)+numRows]
+	} else {
+		value = append(value, make([]T, numRows)...)
+	}
+	rows := value[start:]
+	for _, m := range s.mappings {
+		data := m.data.Call(nil)[0]
+		if data.Len() != numRows {
+			return value[:start], fmt.Errorf("struct scanner: field %v: got %d rows, expected %d rows",
+				m.fieldIndex, data.Len(), numRows)
+		}
+		for i := 0; i < numRows; i++ {
+			v := data.Index(i)
+			if m.convert {
+				v = v.Convert(m.fieldType)
+			}
+			reflect.ValueOf(&rows[i]).Elem().FieldByIndex(m.fieldIndex).Set(v)
+		}
+	}
+	return value, nil
+}
+
+func (s *StructScanner[T]) sameColumns(columns []column.ColumnBasic) bool {
+	if len(columns) != len(s.columns) {
+		return false
+	}
+	for i, col := range columns {
+		if col != s.columns[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *StructScanner[T]) prepare(columns []column.ColumnBasic) error {
+	s.columns = append(s.columns[:0], columns...)
+	s.mappings = s.mappings[:0]
+	var tmpValue T
+	t := reflect.TypeOf(tmpValue)
+	for _, col := range columns {
+		index, ok := s.fields[string(col.Name())]
+		if !ok {
+			continue
+		}
+		m, err := newScanMapping(col, t.FieldByIndex(index).Type)
+		if err != nil {
+			s.columns = s.columns[:0]
+			return err
+		}
+		m.fieldIndex = index
+		s.mappings = append(s.mappings, m)
+	}
+	return nil
+}
+
+func newScanMapping(col column.ColumnBasic, fieldType reflect.Type) (scanMapping, error) {
+	colValue := reflect.ValueOf(col)
+	methods := []string{"Data"}
+	if fieldType.Kind() == reflect.Pointer {
+		methods = []string{"DataP", "Data"}
+	}
+	for _, method := range methods {
+		data := colValue.MethodByName(method)
+		if !data.IsValid() || data.Type().NumIn() != 0 || data.Type().NumOut() != 1 ||
+			data.Type().Out(0).Kind() != reflect.Slice {
+			continue
+		}
+		elemType := data.Type().Out(0).Elem()
+		if elemType.AssignableTo(fieldType) {
+			return scanMapping{
+				fieldType: fieldType,
+				data:      data,
+			}, nil
+		}
+		if elemType.Kind() == fieldType.Kind() && elemType.ConvertibleTo(fieldType) {
+			return scanMapping{
+				fieldType: fieldType,
+				data:      data,
+				convert:   true,
+			}, nil
+		}
+		return scanMapping{}, &ColumnScanError{
+			Column:    string(col.Name()),
+			ChType:    string(col.Type()),
+			FieldType: fieldType.String(),
+			DataType:  elemType.String(),
+		}
+	}
+	return scanMapping{}, &ColumnScanError{
+		Column:    string(col.Name()),
+		ChType:    string(col.Type()),
+		FieldType: fieldType.String(),
+	}
+}
+
+// ScanStructs reads all the remaining blocks of the select statement into a slice of T.
+//
+// See StructScanner for the details of mapping columns to the fields.
+// The select statement is closed after reading.
+func ScanStructs[T any](stmt SelectStmt) ([]T, error) {
+	defer stmt.Close()
+	scanner := NewStructScanner[T]()
+	var result []T
+	for stmt.Next() {
+		var err error
+		result, err = scanner.Read(stmt, result)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := stmt.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,89 @@
+package chconn
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type scanTestBase struct {
+	ID uint64 `ch:"id"`
+}
+
+type scanTestRow struct {
+	scanTestBase
+	Name     string   `ch:"name"`
+	Nullable *int32   `ch:"nullable"`
+	NotNil   int32    `ch:"nullable_value"`
+	Tags     []string `ch:"tags"`
+	LC       string   `ch:"lc"`
+	Ignored  string   `ch:"-"`
+	Number   uint8
+}
+
+func TestScanStructs(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	conn, err := Connect(context.Background(), connString)
+	require.NoError(t, err)
+
+	stmt, err := conn.SelectWithOption(context.Background(), `SELECT
+		number AS id,
+		toString(number) AS name,
+		if(number % 2 = 0, NULL, toInt32(number)) AS nullable,
+		if(number % 2 = 0, NULL, toInt32(number)) AS nullable_value,
+		[toString(number), 'tag'] AS tags,
+		toLowCardinality(toString(number * 2)) AS lc,
+		toUInt8(number) AS Number,
+		'extra' AS not_in_struct
+	FROM system.numbers LIMIT 10`, &QueryOptions{
+		Settings: Settings{
+			{
+				Name:  "max_block_size",
+				Value: "3",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	rows, err := ScanStructs[scanTestRow](stmt)
+	require.NoError(t, err)
+	require.Len(t, rows, 10)
+	for i, row := range rows {
+		assert.Equal(t, uint64(i), row.ID)
+		assert.Equal(t, uint8(i), row.Number)
+		if i%2 == 0 {
+			assert.Nil(t, row.Nullable)
+			assert.Equal(t, int32(0), row.NotNil)
+		} else {
+			require.NotNil(t, row.Nullable)
+			assert.Equal(t, int32(i), *row.Nullable)
+			assert.Equal(t, int32(i), row.NotNil)
+		}
+		assert.Len(t, row.Tags, 2)
+		assert.Equal(t, "tag", row.Tags[1])
+		assert.Empty(t, row.Ignored)
+	}
+	assert.Equal(t, "9", rows[9].Name)
+	assert.Equal(t, "18", rows[9].LC)
+
+	// mismatch type
+	stmt, err = conn.Select(context.Background(), `SELECT toString(number) AS id FROM system.numbers LIMIT 1`)
+	require.NoError(t, err)
+	_, err = ScanStructs[scanTestRow](stmt)
+	require.EqualError(t, err, `cannot scan column "id" (String) into uint64: column data type is string`)
+	assert.True(t, conn.IsClosed())
+
+	conn, err = Connect(context.Background(), connString)
+	require.NoError(t, err)
+	stmt, err = conn.Select(context.Background(), `SELECT 1 AS id`)
+	require.NoError(t, err)
+	_, err = ScanStructs[int](stmt)
+	require.EqualError(t, err, "struct scanner: int is not a struct")
+	conn.Close()
+}
@@ -0,0 +1,153 @@
+package chconn
+
+import (
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+)
+
+// prefetchResult is a block that is read by the prefetch goroutine.
+type prefetchResult struct {
+	block    *block
+	columns  []column.ColumnBasic
+	err      error
+	finished bool
+	canceled bool
+}
+
+// startPrefetch starts reading the next blocks in a background goroutine.
+// the data is read into two sets of columns that are built from the ClickHouse types.
+// one of them is used by the caller and the other one is filled by the goroutine.
+func (s *selectStmt) startPrefetch(b *block) error {
+	columns, err := s.conn.getColumnsByChType(s.queryOptions, b)
+	if err != nil {
+		s.lastErr = err
+		s.Close()
+		return err
+	}
+	s.prefetchFree = make(chan []column.ColumnBasic, 2)
+	s.prefetchResults = make(chan prefetchResult, 2)
+	s.prefetchFree <- s.columnsForRead
+	s.prefetchFree <- columns
+	go s.prefetchLoop()
+	return nil
+}
+
+func (s *selectStmt) prefetchLoop() {
+	defer close(s.prefetchResults)
+	for columns := range s.prefetchFree {
+		res := s.prefetchBlock(columns)
+		s.prefetchResults <- res
+		if res.block == nil {
+			return
+		}
+	}
+}
+
+// prefetchBlock reads the packets until the next data block and reads its data into the columns.
+func (s *selectStmt) prefetchBlock(columns []column.ColumnBasic) prefetchResult {
+	for {
+		if s.ctx.Err() != nil {
+			if err := s.conn.cancelQuery(columns...); err != nil {
+				return prefetchResult{err: preferContextOverNetTimeoutError(s.ctx, err)}
+			}
+			return prefetchResult{canceled: true}
+		}
+		s.conn.reader.SetCompress(false)
+		res, err := s.conn.receiveAndProcessData(nil)
+		if err != nil {
+			if s.conn.isCancelException(err) {
+				return prefetchResult{err: err, canceled: true}
+			}
+			return prefetchResult{err: err}
+		}
+
+		switch v := res.(type) {
+		case *block:
+			if v.NumRows == 0 {
+				if err := v.readColumns(s.conn); err != nil {
+					return prefetchResult{err: err}
+				}
+				continue
+			}
+			if int(v.NumColumns) != len(columns) {
+				return prefetchResult{err: &ColumnNumberReadError{
+					Read:      len(columns),
+					Available: v.NumColumns,
+				}}
+			}
+			if err := v.readColumnsData(s.conn, true, columns...); err != nil {
+				return prefetchResult{err: preferContextOverNetTimeoutError(s.ctx, err)}
+			}
+			return prefetchResult{
+				block: &block{
+					NumRows:    v.NumRows,
+					NumColumns: v.NumColumns,
+				},
+				columns: columns,
+			}
+		case *Profile:
+			if s.queryOptions.OnProfile != nil {
+				s.queryOptions.OnProfile(v)
+			}
+		case *Progress:
+			if s.queryOptions.OnProgress != nil {
+				s.queryOptions.OnProgress(v)
+			}
+		case *ProfileEvent:
+			if s.queryOptions.OnProfileEvent != nil {
+				s.queryOptions.OnProfileEvent(v)
+			}
+		case nil:
+			if s.conn.isCanceled() {
+				return prefetchResult{canceled: true}
+			}
+			return prefetchResult{finished: true}
+		default:
+			return prefetchResult{err: &unexpectedPacket{expected: "serverData", actual: res}}
+		}
+	}
+}
+
+func (s *selectStmt) nextPrefetch() bool {
+	// give back the columns of the previous block to the goroutine
+	if s.block != nil {
+		s.prefetchFree <- s.columnsForRead
+		s.block = nil
+	}
+	res, ok := <-s.prefetchResults
+	switch {
+	case !ok:
+		// the goroutine is stopped by Close
+		return false
+	case res.canceled:
+		s.finishCancel(res.err)
+		return false
+	case res.err != nil:
+		s.lastErr = res.err
+		s.Close()
+		return false
+	case res.finished:
+		s.finishSelect = true
+		s.columnsForRead = nil
+		s.Close()
+		return false
+	}
+	s.block = res.block
+	s.columnsForRead = res.columns
+	return true
+}
+
+// stopPrefetch stops the prefetch goroutine and waits for it.
+func (s *selectStmt) stopPrefetch() {
+	if s.prefetchFree == nil {
+		return
+	}
+	close(s.prefetchFree)
+	if !s.finishSelect {
+		// the goroutine may wait for the server. the connection is closed anyway when the select is not finished.
+		s.conn.conn.Close()
+	}
+	// wait for the goroutine to exit
+	for range s.prefetchResults {
+	}
+	s.prefetchFree = nil
+}
@@ -26,6 +26,26 @@ func (ch *conn) SelectWithOption(
 	queryOptions *QueryOptions,
 	columns ...column.ColumnBasic,
 ) (SelectStmt, error) {
+	ctx, endQuery := ch.startQuery(ctx, OperationSelect, query, queryOptions)
+	s, err := ch.selectWithOption(ctx, query, queryOptions, columns...)
+	if err != nil {
+		endQuery(err)
+		return nil, err
+	}
+	s.endQuery = endQuery
+	return s, nil
+}
+
+func (ch *conn) selectWithOption(
+	ctx context.Context,
+	query string,
+	queryOptions *QueryOptions,
+	columns ...column.ColumnBasic,
+) (*selectStmt, error) {
+	if queryOptions != nil && queryOptions.Prefetch && len(columns) > 0 {
+		return nil, ErrPrefetchColumns
+	}
+
 	err := ch.lock()
 	if err != nil {
 		return nil, err
@@ -51,7 +71,7 @@ func (ch *conn) SelectWithOption(
 		queryOptions = emptyQueryOptions
 	}
 
-	err = ch.sendQueryWithOption(query, queryOptions.QueryID, queryOptions.Settings, queryOptions.Parameters)
+	err = ch.sendQueryWithOption(ctx, query, queryOptions)
 	if err != nil {
 		hasError = true
 		return nil, preferContextOverNetTimeoutError(ctx, err)
@@ -61,11 +81,16 @@ func (ch *conn) SelectWithOption(
 		query:          query,
 		queryOptions:   queryOptions,
 		clientInfo:     nil,
+		stats:          ch.stats,
 		ctx:            ctx,
 		columnsForRead: columns,
 	}
 	res, err := s.conn.receiveAndProcessData(nil)
 	if err != nil {
+		if s.conn.isCancelException(err) {
+			s.finishCancel(err)
+			return nil, s.lastErr
+		}
 		s.lastErr = err
 		s.Close()
 		return nil, err
@@ -76,6 +101,11 @@ func (ch *conn) SelectWithOption(
 			if err != nil {
 				return nil, err
 			}
+			if queryOptions.Prefetch {
+				if err := s.startPrefetch(block); err != nil {
+					return nil, err
+				}
+			}
 			return s, nil
 		}
 	}
@@ -95,6 +125,8 @@ type SelectStmt interface {
 	RowsInBlock() int
 	// Columns return the columns of this select statement.
 	Columns() []column.ColumnBasic
+	// Stats returns the statistics of the query. they are complete when Next returns false.
+	Stats() *QueryStats
 	// Close close the statement and release the connection
 	// If Next is called and returns false and there are no further blocks,
 	// the Rows are closed automatically and it will suffice to check the result of Err.
@@ -114,6 +146,13 @@ type selectStmt struct {
 	ctx            context.Context
 	finishSelect   bool
 	validateData   bool
+	canceled       bool
+	stats          *QueryStats
+	// endQuery ends the client span and reports the end of the query to the observer on Close
+	endQuery func(error)
+
+	prefetchFree    chan []column.ColumnBasic
+	prefetchResults chan prefetchResult
 }
 
 var _ SelectStmt = &selectStmt{}
@@ -126,7 +165,7 @@ func (s *selectStmt) readEmptyBlock(b *block) error {
 		return err
 	}
 	if len(s.columnsForRead) == 0 {
-		s.columnsForRead, err = s.getColumnsByChType(b)
+		s.columnsForRead, err = s.conn.getColumnsByChType(s.queryOptions, b)
 		if err != nil {
 			s.lastErr = err
 			s.Close()
@@ -146,9 +185,20 @@ func (s *selectStmt) Next() bool {
 	if s.closed {
 		return false
 	}
+	if s.prefetchFree != nil {
+		return s.nextPrefetch()
+	}
+	if s.ctx.Err() != nil {
+		s.cancel()
+		return false
+	}
 	s.conn.reader.SetCompress(false)
 	res, err := s.conn.receiveAndProcessData(nil)
 	if err != nil {
+		if s.conn.isCancelException(err) {
+			s.finishCancel(err)
+			return false
+		}
 		s.lastErr = err
 		s.Close()
 		return false
@@ -204,6 +254,11 @@ func (s *selectStmt) Next() bool {
 	}
 
 	if res == nil {
+		if s.conn.isCanceled() {
+			// the result may be incomplete
+			s.finishCancel(nil)
+			return false
+		}
 		s.finishSelect = true
 		s.columnsForRead = nil
 		s.Close()
@@ -215,6 +270,25 @@ func (s *selectStmt) Next() bool {
 	return false
 }
 
+// cancel asks the server to stop the query and drains the remaining packets. so the connection can be reused.
+func (s *selectStmt) cancel() {
+	if err := s.conn.cancelQuery(s.columnsForRead...); err != nil {
+		s.lastErr = preferContextOverNetTimeoutError(s.ctx, err)
+		s.Close()
+		return
+	}
+	s.finishCancel(nil)
+}
+
+// finishCancel closes the statement after the server stopped the cancelled query and keeps the connection.
+func (s *selectStmt) finishCancel(err error) {
+	s.canceled = true
+	s.finishSelect = true
+	s.columnsForRead = nil
+	s.lastErr = newCanceledError(s.ctx, err)
+	s.Close()
+}
+
 func (s *selectStmt) validate() error {
 	if int(s.block.NumColumns) != len(s.columnsForRead) {
 		return &ColumnNumberReadError{
@@ -241,25 +315,34 @@ func (s *selectStmt) Err() error {
 // the Rows are closed automatically and it will suffice to check the result of Err.
 // Close is idempotent and does not affect the result of Err.
 func (s *selectStmt) Close() {
+	s.stopPrefetch()
 	s.conn.reader.SetCompress(false)
 	if !s.closed {
 		s.closed = true
 		s.conn.contextWatcher.Unwatch()
 		s.conn.unlock()
-		if s.Err() != nil || !s.finishSelect {
+		if (s.Err() != nil && !s.canceled) || !s.finishSelect {
 			s.conn.Close()
 		}
+		if s.endQuery != nil {
+			s.endQuery(s.Err())
+		}
 	}
 }
 
+func (s *selectStmt) Stats() *QueryStats {
+	return s.stats
+}
+
 func (s *selectStmt) Columns() []column.ColumnBasic {
 	return s.columnsForRead
 }
 
-func (s *selectStmt) getColumnsByChType(b *block) ([]column.ColumnBasic, error) {
+// getColumnsByChType create the columns of the block from the ClickHouse types.
+func (ch *conn) getColumnsByChType(queryOptions *QueryOptions, b *block) ([]column.ColumnBasic, error) {
 	columns := make([]column.ColumnBasic, len(b.Columns))
 	for i, col := range b.Columns {
-		columnByType, err := s.columnByType(col.ChType, 0, false, false)
+		columnByType, err := ch.columnByType(queryOptions, col.ChType, 0, false, false)
 		if err != nil {
 			return nil, err
 		}
@@ -274,13 +357,24 @@ func (s *selectStmt) getColumnsByChType(b *block) ([]column.ColumnBasic, error)
 	return columns, nil
 }
 
+// columnByType create a column for the ClickHouse type.
+//
 //nolint:funlen,gocyclo
-func (s *selectStmt) columnByType(chType []byte, arrayLevel int, nullable, lc bool) (column.ColumnBasic, error) {
+func (ch *conn) columnByType(
+	queryOptions *QueryOptions,
+	chType []byte,
+	arrayLevel int,
+	nullable, lc bool,
+) (column.ColumnBasic, error) {
 	switch {
-	case string(chType) == "Int8" || helper.IsEnum8(chType):
+	case string(chType) == "Int8":
 		return column.New[int8]().Elem(arrayLevel, nullable, lc), nil
-	case string(chType) == "Int16" || helper.IsEnum16(chType):
+	case helper.IsEnum8(chType):
+		return column.NewEnum8().Elem(arrayLevel, nullable, lc), nil
+	case string(chType) == "Int16":
 		return column.New[int16]().Elem(arrayLevel, nullable, lc), nil
+	case helper.IsEnum16(chType):
+		return column.NewEnum16().Elem(arrayLevel, nullable, lc), nil
 	case string(chType) == "Int32":
 		return column.New[int32]().Elem(arrayLevel, nullable, lc), nil
 	case string(chType) == "Int64":
@@ -305,6 +399,10 @@ func (s *selectStmt) columnByType(chType []byte, arrayLevel int, nullable, lc bo
 		return column.New[float32]().Elem(arrayLevel, nullable, lc), nil
 	case string(chType) == "Float64":
 		return column.New[float64]().Elem(arrayLevel, nullable, lc), nil
+	case string(chType) == "BFloat16":
+		return column.NewBFloat16().Elem(arrayLevel, nullable, lc), nil
+	case string(chType) == "Bool":
+		return column.NewBool().Elem(arrayLevel, nullable, lc), nil
 	case string(chType) == "String":
 		return column.NewString().Elem(arrayLevel, nullable, lc), nil
 	case helper.IsFixedString(chType):
@@ -314,17 +412,17 @@ func (s *selectStmt) columnByType(chType []byte, arrayLevel int, nullable, lc bo
 		}
 		return getFixedType(strLen, arrayLevel, nullable, lc)
 	case string(chType) == "Date":
-		if !s.queryOptions.UseGoTime {
+		if !queryOptions.UseGoTime {
 			return column.New[types.Date]().Elem(arrayLevel, nullable, lc), nil
 		}
 		return column.NewDate[types.Date]().Elem(arrayLevel, nullable, lc), nil
 	case string(chType) == "Date32":
-		if !s.queryOptions.UseGoTime {
+		if !queryOptions.UseGoTime {
 			return column.New[types.Date32]().Elem(arrayLevel, nullable, lc), nil
 		}
 		return column.NewDate[types.Date32]().Elem(arrayLevel, nullable, lc), nil
 	case string(chType) == "DateTime" || helper.IsDateTimeWithParam(chType):
-		if !s.queryOptions.UseGoTime {
+		if !queryOptions.UseGoTime {
 			return column.New[types.DateTime]().Elem(arrayLevel, nullable, lc), nil
 		}
 		var params [][]byte
@@ -335,13 +433,13 @@ func (s *selectStmt) columnByType(chType []byte, arrayLevel int, nullable, lc bo
 		if len(params) > 0 && len(params[0]) >= 3 {
 			if loc, err := time.LoadLocation(string(params[0][1 : len(params[0])-1])); err == nil {
 				col.SetLocation(loc)
-			} else if loc, err := time.LoadLocation(s.conn.serverInfo.Timezone); err == nil {
+			} else if loc, err := time.LoadLocation(ch.serverInfo.Timezone); err == nil {
 				col.SetLocation(loc)
 			}
 		}
 		return col.Elem(arrayLevel, nullable, lc), nil
 	case helper.IsDateTime64(chType):
-		if !s.queryOptions.UseGoTime {
+		if !queryOptions.UseGoTime {
 			return column.New[types.DateTime64]().Elem(arrayLevel, nullable, lc), nil
 		}
 		params := bytes.Split(chType[helper.DateTime64StrLen:len(chType)-1], []byte(", "))
@@ -357,11 +455,30 @@ func (s *selectStmt) columnByType(chType []byte, arrayLevel int, nullable, lc bo
 		if len(params) > 1 && len(params[1]) >= 3 {
 			if loc, err := time.LoadLocation(string(params[1][1 : len(params[1])-1])); err == nil {
 				col.SetLocation(loc)
-			} else if loc, err := time.LoadLocation(s.conn.serverInfo.Timezone); err == nil {
+			} else if loc, err := time.LoadLocation(ch.serverInfo.Timezone); err == nil {
 				col.SetLocation(loc)
 			}
 		}
 		return col.Elem(arrayLevel, nullable, lc), nil
+	case helper.IsTime(chType):
+		if !queryOptions.UseGoTime {
+			return column.New[types.Time]().Elem(arrayLevel, nullable, lc), nil
+		}
+		return column.NewDuration[types.Time]().Elem(arrayLevel, nullable, lc), nil
+	case helper.IsTime64(chType):
+		if !queryOptions.UseGoTime {
+			return column.New[types.Time64]().Elem(arrayLevel, nullable, lc), nil
+		}
+		return column.NewDuration[types.Time64]().Elem(arrayLevel, nullable, lc), nil
+	case helper.IsInterval(chType):
+		switch string(chType) {
+		case "IntervalMonth", "IntervalQuarter", "IntervalYear":
+			return column.New[types.Interval]().Elem(arrayLevel, nullable, lc), nil
+		}
+		if !queryOptions.UseGoTime {
+			return column.New[types.Interval]().Elem(arrayLevel, nullable, lc), nil
+		}
+		return column.NewDuration[types.Interval]().Elem(arrayLevel, nullable, lc), nil
 
 	case helper.IsDecimal(chType):
 		params := bytes.Split(chType[helper.DecimalStrLen:len(chType)-1], []byte(", "))
@@ -387,25 +504,57 @@ func (s *selectStmt) columnByType(chType []byte, arrayLevel int, nullable, lc bo
 		return column.New[types.IPv4]().Elem(arrayLevel, nullable, lc), nil
 	case string(chType) == "IPv6":
 		return column.New[types.IPv6]().Elem(arrayLevel, nullable, lc), nil
+	case helper.IsPoint(chType):
+		return column.NewPoint().Elem(arrayLevel), nil
+	case helper.IsRing(chType), helper.IsLineString(chType):
+		return column.NewPoint().Elem(arrayLevel + 1), nil
+	case helper.IsPolygon(chType), helper.IsMultiLineString(chType):
+		return column.NewPoint().Elem(arrayLevel + 2), nil
+	case helper.IsMultiPolygon(chType):
+		return column.NewPoint().Elem(arrayLevel + 3), nil
+	case helper.IsJSON(chType) || helper.IsObject(chType):
+		col := column.NewJSON().SetColumnByType(func(chType []byte) (column.ColumnBasic, error) {
+			return ch.columnByType(queryOptions, chType, 0, false, false)
+		})
+		// the typed paths are needed before validate (on reading the header of the first block)
+		col.SetType(chType)
+		return col.Elem(arrayLevel), nil
+	case helper.IsDynamic(chType):
+		return column.NewDynamic().SetColumnByType(func(chType []byte) (column.ColumnBasic, error) {
+			return ch.columnByType(queryOptions, chType, 0, false, false)
+		}).Elem(arrayLevel), nil
+	case helper.IsAggregateFunction(chType):
+		return column.NewAggregateFunction().Elem(arrayLevel), nil
+	case helper.IsVariant(chType):
+		columnsVariant, err := helper.TypesInParentheses(chType[helper.LenVariantStr : len(chType)-1])
+		if err != nil {
+			return nil, fmt.Errorf("variant invalid types: %w", err)
+		}
+		columns := make([]column.ColumnBasic, len(columnsVariant))
+		for i, c := range columnsVariant {
+			col, err := ch.columnByType(queryOptions, c.ChType, 0, false, false)
+			if err != nil {
+				return nil, err
+			}
+			columns[i] = col
+		}
+		return column.NewVariant(columns...).Elem(arrayLevel), nil
 
 	case helper.IsNullable(chType):
-		return s.columnByType(chType[helper.LenNullableStr:len(chType)-1], arrayLevel, true, lc)
+		return ch.columnByType(queryOptions, chType[helper.LenNullableStr:len(chType)-1], arrayLevel, true, lc)
 
 	case bytes.HasPrefix(chType, []byte("SimpleAggregateFunction(")):
-		return s.columnByType(helper.FilterSimpleAggregate(chType), arrayLevel, nullable, lc)
+		return ch.columnByType(queryOptions, helper.FilterSimpleAggregate(chType), arrayLevel, nullable, lc)
 	case helper.IsArray(chType):
-		if arrayLevel == 3 {
-			return nil, fmt.Errorf("max array level is 3")
-		}
 		if nullable {
 			return nil, fmt.Errorf("array is not allowed in nullable")
 		}
 		if lc {
 			return nil, fmt.Errorf("LowCardinality is not allowed in nullable")
 		}
-		return s.columnByType(chType[helper.LenArrayStr:len(chType)-1], arrayLevel+1, nullable, lc)
+		return ch.columnByType(queryOptions, chType[helper.LenArrayStr:len(chType)-1], arrayLevel+1, nullable, lc)
 	case helper.IsLowCardinality(chType):
-		return s.columnByType(chType[helper.LenLowCardinalityStr:len(chType)-1], arrayLevel, nullable, true)
+		return ch.columnByType(queryOptions, chType[helper.LenLowCardinalityStr:len(chType)-1], arrayLevel, nullable, true)
 	case helper.IsTuple(chType):
 		columnsTuple, err := helper.TypesInParentheses(chType[helper.LenTupleStr : len(chType)-1])
 		if err != nil {
@@ -413,7 +562,7 @@ func (s *selectStmt) columnByType(chType []byte, arrayLevel int, nullable, lc bo
 		}
 		columns := make([]column.ColumnBasic, len(columnsTuple))
 		for i, c := range columnsTuple {
-			col, err := s.columnByType(c.ChType, 0, false, false)
+			col, err := ch.columnByType(queryOptions, c.ChType, 0, false, false)
 			if err != nil {
 				return nil, err
 			}
@@ -431,7 +580,7 @@ func (s *selectStmt) columnByType(chType []byte, arrayLevel int, nullable, lc bo
 		}
 		columns := make([]column.ColumnBasic, len(columnsMap))
 		for i, col := range columnsMap {
-			col, err := s.columnByType(col.ChType, arrayLevel, nullable, lc)
+			col, err := ch.columnByType(queryOptions, col.ChType, arrayLevel, nullable, lc)
 			if err != nil {
 				return nil, err
 			}
@@ -439,7 +588,7 @@ func (s *selectStmt) columnByType(chType []byte, arrayLevel int, nullable, lc bo
 		}
 		return column.NewMapBase(columns[0], columns[1]), nil
 	case helper.IsNested(chType):
-		return s.columnByType(helper.NestedToArrayType(chType), arrayLevel, nullable, lc)
+		return ch.columnByType(queryOptions, helper.NestedToArrayType(chType), arrayLevel, nullable, lc)
 	}
 	return nil, fmt.Errorf("unknown type: %s", chType)
 }
@@ -93,6 +93,109 @@ func TestSelectCtxError(t *testing.T) {
 	assert.True(t, c.IsClosed())
 }
 
+func TestSelectCancel(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	c, err := Connect(context.Background(), connString)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	colNumber := column.New[uint64]()
+	res, err := c.Select(ctx, "SELECT number FROM system.numbers", colNumber)
+	require.NoError(t, err)
+	require.True(t, res.Next())
+	cancel()
+	for res.Next() {
+	}
+	require.ErrorIs(t, res.Err(), context.Canceled)
+	res.Close()
+	assert.False(t, c.IsClosed())
+
+	// the connection is still usable after cancel
+	res, err = c.Select(context.Background(), "SELECT number FROM system.numbers LIMIT 5", colNumber)
+	require.NoError(t, err)
+	var numbers []uint64
+	for res.Next() {
+		numbers = colNumber.Read(numbers)
+	}
+	require.NoError(t, res.Err())
+	assert.Equal(t, []uint64{0, 1, 2, 3, 4}, numbers)
+	c.Close()
+}
+
+func TestSelectPrefetch(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	c, err := Connect(context.Background(), connString)
+	require.NoError(t, err)
+
+	queryOptions := &QueryOptions{
+		Prefetch: true,
+		Settings: Settings{
+			{
+				Name:  "max_block_size",
+				Value: "1000",
+			},
+		},
+	}
+	// the columns are built from the ClickHouse types with prefetch
+	colNumber := column.New[uint64]()
+	res, err := c.SelectWithOption(context.Background(),
+		"SELECT number FROM system.numbers LIMIT 100000",
+		queryOptions,
+		colNumber,
+	)
+	require.ErrorIs(t, err, ErrPrefetchColumns)
+	require.Nil(t, res)
+	assert.False(t, c.IsClosed())
+	assert.False(t, c.IsBusy())
+
+	res, err = c.SelectWithOption(context.Background(),
+		"SELECT number FROM system.numbers LIMIT 100000",
+		queryOptions,
+	)
+	require.NoError(t, err)
+	var rows int
+	var sum uint64
+	for res.Next() {
+		col, ok := res.Columns()[0].(*column.Base[uint64])
+		require.True(t, ok)
+		require.Equal(t, res.RowsInBlock(), col.NumRow())
+		rows += col.NumRow()
+		for _, v := range col.Data() {
+			sum += v
+		}
+	}
+	require.NoError(t, res.Err())
+	assert.Equal(t, 100000, rows)
+	assert.Equal(t, uint64(100000*99999/2), sum)
+	assert.False(t, c.IsClosed())
+
+	// cancel with prefetch
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	res, err = c.SelectWithOption(ctx, "SELECT number FROM system.numbers", queryOptions)
+	require.NoError(t, err)
+	require.True(t, res.Next())
+	cancel()
+	for res.Next() {
+	}
+	require.ErrorIs(t, res.Err(), context.Canceled)
+	assert.False(t, c.IsClosed())
+
+	// close before the end of the select
+	res, err = c.SelectWithOption(context.Background(), "SELECT number FROM system.numbers", queryOptions)
+	require.NoError(t, err)
+	require.True(t, res.Next())
+	res.Close()
+	assert.True(t, c.IsClosed())
+}
+
 func TestSelectProgress(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,126 @@
+package chconn
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+)
+
+// LogPriority is the priority of a server log entry.
+type LogPriority int8
+
+// The priorities of the server log entries (the Poco::Message priorities).
+const (
+	LogPriorityFatal       LogPriority = 1
+	LogPriorityCritical    LogPriority = 2
+	LogPriorityError       LogPriority = 3
+	LogPriorityWarning     LogPriority = 4
+	LogPriorityNotice      LogPriority = 5
+	LogPriorityInformation LogPriority = 6
+	LogPriorityDebug       LogPriority = 7
+	LogPriorityTrace       LogPriority = 8
+	LogPriorityTest        LogPriority = 9
+)
+
+func (p LogPriority) String() string {
+	switch p {
+	case LogPriorityFatal:
+		return "Fatal"
+	case LogPriorityCritical:
+		return "Critical"
+	case LogPriorityError:
+		return "Error"
+	case LogPriorityWarning:
+		return "Warning"
+	case LogPriorityNotice:
+		return "Notice"
+	case LogPriorityInformation:
+		return "Information"
+	case LogPriorityDebug:
+		return "Debug"
+	case LogPriorityTrace:
+		return "Trace"
+	case LogPriorityTest:
+		return "Test"
+	}
+	return "LogPriority(" + strconv.Itoa(int(p)) + ")"
+}
+
+// ServerLog is a log entry of the server for the query.
+// The server sends the logs when the send_logs_level setting is set (e.g. "debug").
+type ServerLog struct {
+	Time     time.Time
+	Host     string
+	QueryID  string
+	ThreadID uint64
+	Priority LogPriority
+	Source   string
+	Text     string
+}
+
+// serverLogBlock is the columns of the log packet
+type serverLogBlock struct {
+	time             *column.Base[uint32]
+	timeMicroseconds *column.Base[uint32]
+	host             *column.String
+	queryID          *column.String
+	threadID         *column.Base[uint64]
+	priority         *column.Base[int8]
+	source           *column.String
+	text             *column.String
+}
+
+func newServerLogBlock() *serverLogBlock {
+	return &serverLogBlock{
+		time:             column.New[uint32](),
+		timeMicroseconds: column.New[uint32](),
+		host:             column.NewString(),
+		queryID:          column.NewString(),
+		threadID:         column.New[uint64](),
+		priority:         column.New[int8](),
+		source:           column.NewString(),
+		text:             column.NewString(),
+	}
+}
+
+func (l *serverLogBlock) read(c *conn) error {
+	return c.block.readColumnsData(c, true,
+		l.time, l.timeMicroseconds, l.host, l.queryID, l.threadID, l.priority, l.source, l.text)
+}
+
+// readServerLog reads a log packet and passes its entries to onLog.
+// the log packets are never compressed.
+func (ch *conn) readServerLog(onLog func(*ServerLog)) error {
+	if ch.serverLog == nil {
+		ch.serverLog = newServerLogBlock()
+	}
+	ch.block.reset()
+	oldCompress := ch.compress
+	defer func() {
+		ch.compress = oldCompress
+	}()
+	ch.compress = false
+	if err := ch.block.read(ch); err != nil {
+		return err
+	}
+	l := ch.serverLog
+	if err := l.read(ch); err != nil {
+		return err
+	}
+	if onLog == nil {
+		return nil
+	}
+	for i := 0; i < l.time.NumRow(); i++ {
+		onLog(&ServerLog{
+			Time:     time.Unix(int64(l.time.Row(i)), int64(l.timeMicroseconds.Row(i))*int64(time.Microsecond)),
+			Host:     l.host.Row(i),
+			QueryID:  l.queryID.Row(i),
+			ThreadID: l.threadID.Row(i),
+			Priority: LogPriority(l.priority.Row(i)),
+			Source:   l.source.Row(i),
+			Text:     l.text.Row(i),
+		})
+	}
+	return nil
+}
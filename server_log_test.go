@@ -0,0 +1,69 @@
+package chconn
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+)
+
+func TestServerLog(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	c, err := Connect(context.Background(), connString)
+	require.NoError(t, err)
+	defer c.Close()
+
+	var logs []*ServerLog
+	settings := Settings{
+		{
+			Name:  "send_logs_level",
+			Value: "debug",
+		},
+	}
+	col := column.New[uint64]()
+	stmt, err := c.SelectWithOption(context.Background(), "SELECT number FROM system.numbers LIMIT 10", &QueryOptions{
+		QueryID:  "test_server_log",
+		Settings: settings,
+		OnLog: func(l *ServerLog) {
+			logs = append(logs, l)
+		},
+	}, col)
+	require.NoError(t, err)
+	var n int
+	for stmt.Next() {
+		n += col.NumRow()
+	}
+	require.NoError(t, stmt.Err())
+	stmt.Close()
+	assert.Equal(t, 10, n)
+
+	require.NotEmpty(t, logs)
+	for _, l := range logs {
+		assert.Equal(t, "test_server_log", l.QueryID)
+		assert.NotEmpty(t, l.Text)
+		assert.GreaterOrEqual(t, l.Priority, LogPriorityFatal)
+		assert.LessOrEqual(t, l.Priority, LogPriorityDebug)
+		assert.WithinDuration(t, time.Now(), l.Time, time.Hour)
+	}
+
+	// the logs are read without OnLog too
+	stmt, err = c.SelectWithOption(context.Background(), "SELECT number FROM system.numbers LIMIT 10", &QueryOptions{
+		Settings: settings,
+	}, col)
+	require.NoError(t, err)
+	for stmt.Next() {
+	}
+	require.NoError(t, stmt.Err())
+	stmt.Close()
+	require.NoError(t, c.Ping(context.Background()))
+
+	assert.Equal(t, "Information", LogPriorityInformation.String())
+	assert.Equal(t, "LogPriority(20)", LogPriority(20).String())
+}
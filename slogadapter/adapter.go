@@ -0,0 +1,61 @@
+//go:build go1.21
+
+// Package slogadapter provides a logger that writes to a log/slog.Logger.
+// It needs Go 1.21 or later (log/slog). it is excluded from the builds with the older versions.
+package slogadapter
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+
+	"github.com/vahid-sohrabloo/chconn/v2"
+)
+
+// Logger is a chconn.Logger that writes to a slog.Logger.
+type Logger struct {
+	l *slog.Logger
+}
+
+var _ chconn.Logger = &Logger{}
+
+// NewLogger returns a new logger that writes to l. slog.Default() is used if l is nil.
+func NewLogger(l *slog.Logger) *Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return &Logger{l: l}
+}
+
+// Log implements chconn.Logger. the data is written as attributes sorted by key.
+func (l *Logger) Log(ctx context.Context, level chconn.LogLevel, msg string, data map[string]any) {
+	slogLevel := Level(level)
+	if !l.l.Enabled(ctx, slogLevel) {
+		return
+	}
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	attrs := make([]slog.Attr, len(keys))
+	for i, k := range keys {
+		attrs[i] = slog.Any(k, data[k])
+	}
+	l.l.LogAttrs(ctx, slogLevel, msg, attrs...)
+}
+
+// Level converts a chconn log level to a slog level. LogLevelTrace is converted to slog.LevelDebug-4.
+func Level(level chconn.LogLevel) slog.Level {
+	switch level {
+	case chconn.LogLevelTrace:
+		return slog.LevelDebug - 4
+	case chconn.LogLevelDebug:
+		return slog.LevelDebug
+	case chconn.LogLevelInfo:
+		return slog.LevelInfo
+	case chconn.LogLevelWarn:
+		return slog.LevelWarn
+	}
+	return slog.LevelError
+}
@@ -0,0 +1,67 @@
+//go:build go1.21
+
+package slogadapter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2"
+)
+
+func TestLogger(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	l := NewLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})))
+
+	l.Log(context.Background(), chconn.LogLevelDebug, "Exec", nil)
+	assert.Empty(t, buf.String())
+
+	l.Log(context.Background(), chconn.LogLevelError, "Select", map[string]any{
+		"sql":      "SELECT 1",
+		"duration": time.Second,
+		"err":      errors.New("failed"),
+	})
+	assert.Equal(t, "level=ERROR msg=Select duration=1s err=failed sql=\"SELECT 1\"\n", buf.String())
+
+	assert.Equal(t, slog.LevelDebug-4, Level(chconn.LogLevelTrace))
+	assert.Equal(t, slog.LevelWarn, Level(chconn.LogLevelWarn))
+}
+
+func TestLoggerConn(t *testing.T) {
+	t.Parallel()
+
+	config, err := chconn.ParseConfig(os.Getenv("CHX_TEST_TCP_CONN_STRING"))
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	config.Logger = NewLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	c, err := chconn.ConnectConfig(context.Background(), config)
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.ExecWithOption(context.Background(), "DROP TABLE IF EXISTS test_logger_not_exists", &chconn.QueryOptions{
+		QueryID: "test_logger_conn",
+	}))
+	assert.Contains(t, buf.String(), `level=INFO msg=Exec duration=`)
+
+	buf.Reset()
+	require.Error(t, c.Exec(context.Background(), "SELECT * FROM not_found_table"))
+	assert.Contains(t, buf.String(), `level=ERROR msg=Exec duration=`)
+	assert.Contains(t, buf.String(), "not_found_table")
+}
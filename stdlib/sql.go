@@ -0,0 +1,637 @@
+// Package stdlib is the compatibility layer from chconn to database/sql.
+//
+// A database/sql connection can be established through sql.Open.
+//
+//	db, err := sql.Open("chconn", "password=secret database=default")
+//
+// Or from a chconn.Config or a chpool.Pool.
+//
+//	db := stdlib.OpenDB(config)
+//	db := stdlib.OpenDBFromPool(pool)
+//
+// Select queries use the columns of the select statement. Date and DateTime columns are read as time.Time.
+// Named arguments (sql.Named) are sent as query parameters.
+//
+//	rows, err := db.Query("SELECT number FROM system.numbers LIMIT {limit:UInt64}", sql.Named("limit", 10))
+//
+// Insert queries with arguments insert one row per Exec, the arguments are the values of the columns of the
+// insert query (in the same order). Inside a transaction, the rows of the insert queries are buffered and inserted
+// on Commit with one insert statement for each query.
+//
+//	tx, err := db.Begin()
+//	stmt, err := tx.Prepare("INSERT INTO example (id, name) VALUES")
+//	for _, row := range rows {
+//		_, err = stmt.Exec(row.ID, row.Name)
+//	}
+//	err = tx.Commit()
+//
+// ClickHouse does not support transactions, so Rollback only drops the buffered rows.
+package stdlib
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vahid-sohrabloo/chconn/v2"
+	"github.com/vahid-sohrabloo/chconn/v2/chpool"
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+)
+
+var chconnDriver *Driver
+
+func init() {
+	chconnDriver = &Driver{}
+	sql.Register("chconn", chconnDriver)
+}
+
+// Driver is the database/sql driver of chconn.
+type Driver struct{}
+
+// Open opens a new connection by the connection string.
+func (d *Driver) Open(name string) (driver.Conn, error) {
+	c, err := d.OpenConnector(name)
+	if err != nil {
+		return nil, err
+	}
+	return c.Connect(context.Background())
+}
+
+// OpenConnector parses the connection string and returns a connector.
+func (d *Driver) OpenConnector(name string) (driver.Connector, error) {
+	config, err := chconn.ParseConfig(name)
+	if err != nil {
+		return nil, err
+	}
+	return &connector{
+		config: config,
+	}, nil
+}
+
+// OpenDB opens a database by the chconn config.
+func OpenDB(config *chconn.Config) *sql.DB {
+	return sql.OpenDB(&connector{
+		config: config,
+	})
+}
+
+// OpenDBFromPool opens a database by the pool.
+//
+// The connections are acquired from the pool and released on close.
+func OpenDBFromPool(pool chpool.Pool) *sql.DB {
+	return sql.OpenDB(&connector{
+		pool: pool,
+	})
+}
+
+type connector struct {
+	config *chconn.Config
+	pool   chpool.Pool
+}
+
+// Connect returns a connection to the database.
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	if c.pool != nil {
+		poolConn, err := c.pool.Acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &Conn{
+			conn: poolConn.Conn(),
+			close: func() error {
+				poolConn.Release()
+				return nil
+			},
+		}, nil
+	}
+
+	conn, err := chconn.ConnectConfig(ctx, c.config.Copy())
+	if err != nil {
+		return nil, err
+	}
+	return &Conn{
+		conn:  conn,
+		close: conn.Close,
+	}, nil
+}
+
+// Driver returns the underlying driver of the connector.
+func (c *connector) Driver() driver.Driver {
+	return chconnDriver
+}
+
+// Conn is a database/sql connection of chconn.
+type Conn struct {
+	conn  chconn.Conn
+	close func() error
+	tx    *tx
+}
+
+// Conn returns the underlying chconn.Conn.
+func (c *Conn) Conn() chconn.Conn {
+	return c.conn
+}
+
+// Prepare returns a prepared statement.
+func (c *Conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext returns a prepared statement.
+//
+// The query does not send to the server until Exec or Query.
+func (c *Conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if c.conn.IsClosed() {
+		return nil, driver.ErrBadConn
+	}
+	return &Stmt{
+		conn:  c,
+		query: query,
+	}, nil
+}
+
+// Close closes the connection (or releases it to the pool).
+func (c *Conn) Close() error {
+	return c.close()
+}
+
+// Begin starts a transaction.
+func (c *Conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+// BeginTx starts a transaction.
+//
+// ClickHouse does not support transactions, the transaction only buffers the rows of the insert queries.
+func (c *Conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if c.conn.IsClosed() {
+		return nil, driver.ErrBadConn
+	}
+	if sql.IsolationLevel(opts.Isolation) != sql.LevelDefault || opts.ReadOnly {
+		return nil, errors.New("chconn/stdlib: transaction options are not supported")
+	}
+	if c.tx != nil {
+		return nil, errors.New("chconn/stdlib: transaction already started")
+	}
+	c.tx = &tx{
+		conn: c,
+	}
+	return c.tx, nil
+}
+
+// ExecContext executes a query without returning any rows.
+//
+// Inside a transaction, the rows of the insert queries are buffered until Commit.
+func (c *Conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if c.conn.IsClosed() {
+		return nil, driver.ErrBadConn
+	}
+	if isInsert(query) {
+		if c.tx != nil && len(args) > 0 {
+			c.tx.append(query, namedValues(args))
+			return driver.RowsAffected(1), nil
+		}
+		if len(args) == 0 {
+			err := c.conn.InsertWithOption(ctx, query, nil)
+			if err != nil {
+				return nil, err
+			}
+			return driver.ResultNoRows, nil
+		}
+		err := chconn.InsertValues(ctx, c.conn, query, nil, [][]any{namedValues(args)})
+		if err != nil {
+			return nil, err
+		}
+		return driver.RowsAffected(1), nil
+	}
+
+	queryOptions, err := c.queryOptions(args)
+	if err != nil {
+		return nil, err
+	}
+	err = c.conn.ExecWithOption(ctx, query, queryOptions)
+	if err != nil {
+		return nil, err
+	}
+	return driver.ResultNoRows, nil
+}
+
+// QueryContext executes a select query and returns the rows.
+func (c *Conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if c.conn.IsClosed() {
+		return nil, driver.ErrBadConn
+	}
+	queryOptions, err := c.queryOptions(args)
+	if err != nil {
+		return nil, err
+	}
+	queryOptions.UseGoTime = true
+	stmt, err := c.conn.SelectWithOption(ctx, query, queryOptions)
+	if err != nil {
+		return nil, err
+	}
+	return newRows(stmt)
+}
+
+// Ping checks the connection to the server is alive.
+func (c *Conn) Ping(ctx context.Context) error {
+	if c.conn.IsClosed() {
+		return driver.ErrBadConn
+	}
+	err := c.conn.Ping(ctx)
+	if err != nil {
+		c.Close()
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+// CheckNamedValue accepts all the values without any conversion.
+//
+// The values are converted by the type of the columns (for insert) or the query parameters.
+func (c *Conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if valuer, ok := nv.Value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			return err
+		}
+		nv.Value = v
+	}
+	return nil
+}
+
+// ResetSession is called before the connection is reused.
+func (c *Conn) ResetSession(ctx context.Context) error {
+	if c.conn.IsClosed() {
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+// IsValid reports whether the connection is valid to reuse.
+func (c *Conn) IsValid() bool {
+	return !c.conn.IsClosed()
+}
+
+func (c *Conn) queryOptions(args []driver.NamedValue) (*chconn.QueryOptions, error) {
+	queryOptions := &chconn.QueryOptions{}
+	if len(args) == 0 {
+		return queryOptions, nil
+	}
+	params := make([]chconn.Parameter, len(args))
+	for i, arg := range args {
+		if arg.Name == "" {
+			return nil, fmt.Errorf("chconn/stdlib: positional argument %d is not supported, use sql.Named", arg.Ordinal)
+		}
+		param, err := c.parameter(arg.Name, arg.Value)
+		if err != nil {
+			return nil, err
+		}
+		params[i] = param
+	}
+	queryOptions.Parameters = chconn.NewParameters(params...)
+	return queryOptions, nil
+}
+
+//nolint:gocyclo
+func (c *Conn) parameter(name string, v any) (chconn.Parameter, error) {
+	switch v := v.(type) {
+	case nil:
+		return func() chconn.Setting {
+			return chconn.Setting{
+				Name:   name,
+				Value:  `'\N'`,
+				Custom: true,
+			}
+		}, nil
+	case bool:
+		return func() chconn.Setting {
+			return chconn.Setting{
+				Name:   name,
+				Value:  "'" + strconv.FormatBool(v) + "'",
+				Custom: true,
+			}
+		}, nil
+	case int:
+		return chconn.IntParameter(name, v), nil
+	case int8:
+		return chconn.IntParameter(name, v), nil
+	case int16:
+		return chconn.IntParameter(name, v), nil
+	case int32:
+		return chconn.IntParameter(name, v), nil
+	case int64:
+		return chconn.IntParameter(name, v), nil
+	case uint:
+		return chconn.UintParameter(name, v), nil
+	case uint8:
+		return chconn.UintParameter(name, v), nil
+	case uint16:
+		return chconn.UintParameter(name, v), nil
+	case uint32:
+		return chconn.UintParameter(name, v), nil
+	case uint64:
+		return chconn.UintParameter(name, v), nil
+	case float32:
+		return chconn.Float32Parameter(name, v), nil
+	case float64:
+		return chconn.Float64Parameter(name, v), nil
+	case string:
+		return chconn.StringParameter(name, v), nil
+	case []byte:
+		return chconn.StringParameter(name, string(v)), nil
+	case time.Time:
+		if loc, err := time.LoadLocation(c.conn.ServerInfo().Timezone); err == nil {
+			v = v.In(loc)
+		}
+		return chconn.StringParameter(name, v.Format("2006-01-02 15:04:05.999999999")), nil
+	case []string:
+		return chconn.StringSliceParameter(name, v), nil
+	case []int:
+		return chconn.IntSliceParameter(name, v), nil
+	case []int64:
+		return chconn.IntSliceParameter(name, v), nil
+	case []uint64:
+		return chconn.UintSliceParameter(name, v), nil
+	case []float64:
+		return chconn.Float64SliceParameter(name, v), nil
+	case fmt.Stringer:
+		return chconn.StringParameter(name, v.String()), nil
+	}
+	return nil, fmt.Errorf("chconn/stdlib: unsupported type %T for parameter %q", v, name)
+}
+
+func isInsert(query string) bool {
+	query = strings.TrimLeft(query, " \t\r\n(")
+	return len(query) >= len("INSERT") && strings.EqualFold(query[:len("INSERT")], "INSERT")
+}
+
+func namedValues(args []driver.NamedValue) []any {
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	return values
+}
+
+func driverNamedValues(args []driver.Value) []driver.NamedValue {
+	namedArgs := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		namedArgs[i] = driver.NamedValue{
+			Ordinal: i + 1,
+			Value:   arg,
+		}
+	}
+	return namedArgs
+}
+
+// Stmt is a prepared statement.
+type Stmt struct {
+	conn  *Conn
+	query string
+}
+
+// Close closes the statement.
+//
+// The buffered rows of the transaction are not dropped.
+func (s *Stmt) Close() error {
+	return nil
+}
+
+// NumInput returns -1, the number of arguments is not checked by database/sql.
+func (s *Stmt) NumInput() int {
+	return -1
+}
+
+// Exec executes the statement.
+func (s *Stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), driverNamedValues(args))
+}
+
+// ExecContext executes the statement.
+func (s *Stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.ExecContext(ctx, s.query, args)
+}
+
+// Query executes the select statement.
+func (s *Stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), driverNamedValues(args))
+}
+
+// QueryContext executes the select statement.
+func (s *Stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.QueryContext(ctx, s.query, args)
+}
+
+type insertBatch struct {
+	query string
+	rows  [][]any
+}
+
+type tx struct {
+	conn    *Conn
+	batches []*insertBatch
+}
+
+func (t *tx) append(query string, values []any) {
+	for _, b := range t.batches {
+		if b.query == query {
+			b.rows = append(b.rows, values)
+			return
+		}
+	}
+	t.batches = append(t.batches, &insertBatch{
+		query: query,
+		rows:  [][]any{values},
+	})
+}
+
+// Commit inserts the buffered rows.
+func (t *tx) Commit() error {
+	t.conn.tx = nil
+	for _, b := range t.batches {
+		err := chconn.InsertValues(context.Background(), t.conn.conn, b.query, nil, b.rows)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rollback drops the buffered rows.
+func (t *tx) Rollback() error {
+	t.conn.tx = nil
+	return nil
+}
+
+// Rows is the result of a select query.
+type Rows struct {
+	stmt    chconn.SelectStmt
+	columns []column.ColumnBasic
+	readers []func(row int) any
+	row     int
+	numRow  int
+}
+
+func newRows(stmt chconn.SelectStmt) (*Rows, error) {
+	columns := stmt.Columns()
+	readers := make([]func(row int) any, len(columns))
+	for i, col := range columns {
+		reader, err := rowReader(col)
+		if err != nil {
+			stmt.Close()
+			return nil, err
+		}
+		readers[i] = reader
+	}
+	return &Rows{
+		stmt:    stmt,
+		columns: columns,
+		readers: readers,
+	}, nil
+}
+
+// Columns returns the names of the columns.
+func (r *Rows) Columns() []string {
+	names := make([]string, len(r.columns))
+	for i, col := range r.columns {
+		names[i] = string(col.Name())
+	}
+	return names
+}
+
+// ColumnTypeDatabaseTypeName returns the ClickHouse type of the column.
+func (r *Rows) ColumnTypeDatabaseTypeName(index int) string {
+	return string(r.columns[index].Type())
+}
+
+// Close closes the select statement.
+func (r *Rows) Close() error {
+	r.stmt.Close()
+	return nil
+}
+
+// Next reads the next row into dest.
+func (r *Rows) Next(dest []driver.Value) error {
+	if r.row >= r.numRow {
+		if !r.stmt.Next() {
+			if err := r.stmt.Err(); err != nil {
+				return err
+			}
+			return io.EOF
+		}
+		r.row = 0
+		r.numRow = r.stmt.RowsInBlock()
+	}
+	for i, read := range r.readers {
+		dest[i] = read(r.row)
+	}
+	r.row++
+	return nil
+}
+
+type arrayColumn interface {
+	Offsets() []uint64
+	Column() column.ColumnBasic
+}
+
+type mapColumn interface {
+	Offsets() []uint64
+	KeyColumn() column.ColumnBasic
+	ValueColumn() column.ColumnBasic
+}
+
+type tupleColumn interface {
+	Columns() []column.ColumnBasic
+}
+
+// rowReader create a function to read a row of the column.
+//
+// Nullable columns return nil for NULL. Non-generic array, map and tuple columns return []any, map[any]any and []any.
+func rowReader(col column.ColumnBasic) (func(row int) any, error) {
+	colValue := reflect.ValueOf(col)
+	if rowP := colValue.MethodByName("RowP"); rowP.IsValid() && rowP.Type().Out(0).Kind() == reflect.Pointer {
+		return func(row int) any {
+			v := rowP.Call([]reflect.Value{reflect.ValueOf(row)})[0]
+			if v.IsNil() {
+				return nil
+			}
+			return v.Elem().Interface()
+		}, nil
+	}
+	if rowMethod := colValue.MethodByName("Row"); rowMethod.IsValid() {
+		return func(row int) any {
+			return rowMethod.Call([]reflect.Value{reflect.ValueOf(row)})[0].Interface()
+		}, nil
+	}
+	if rowP := colValue.MethodByName("RowP"); rowP.IsValid() {
+		return func(row int) any {
+			return rowP.Call([]reflect.Value{reflect.ValueOf(row)})[0].Interface()
+		}, nil
+	}
+
+	switch c := col.(type) {
+	case arrayColumn:
+		readElem, err := rowReader(c.Column())
+		if err != nil {
+			return nil, err
+		}
+		return func(row int) any {
+			start, end := offsetRange(c.Offsets(), row)
+			values := make([]any, 0, end-start)
+			for i := start; i < end; i++ {
+				values = append(values, readElem(i))
+			}
+			return values
+		}, nil
+	case mapColumn:
+		readKey, err := rowReader(c.KeyColumn())
+		if err != nil {
+			return nil, err
+		}
+		readValue, err := rowReader(c.ValueColumn())
+		if err != nil {
+			return nil, err
+		}
+		return func(row int) any {
+			start, end := offsetRange(c.Offsets(), row)
+			values := make(map[any]any, end-start)
+			for i := start; i < end; i++ {
+				values[readKey(i)] = readValue(i)
+			}
+			return values
+		}, nil
+	case tupleColumn:
+		columns := c.Columns()
+		readers := make([]func(row int) any, len(columns))
+		for i, tupleCol := range columns {
+			reader, err := rowReader(tupleCol)
+			if err != nil {
+				return nil, err
+			}
+			readers[i] = reader
+		}
+		return func(row int) any {
+			values := make([]any, len(readers))
+			for i, read := range readers {
+				values[i] = read(row)
+			}
+			return values
+		}, nil
+	}
+	return nil, fmt.Errorf("chconn/stdlib: column %q (%s) is not supported", col.Name(), col.Type())
+}
+
+func offsetRange(offsets []uint64, row int) (start, end int) {
+	if row > 0 {
+		start = int(offsets[row-1])
+	}
+	return start, int(offsets[row])
+}
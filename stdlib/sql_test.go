@@ -0,0 +1,123 @@
+package stdlib
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2/chpool"
+)
+
+func TestSQLInsertAndSelect(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	db, err := sql.Open("chconn", connString)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`DROP TABLE IF EXISTS test_stdlib`)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE test_stdlib (
+				id UInt64,
+				name String,
+				nullable Nullable(Int32),
+				tags Array(String),
+				created DateTime
+			) Engine=Memory`)
+	require.NoError(t, err)
+
+	created := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	stmt, err := tx.Prepare(`INSERT INTO test_stdlib (id, name, nullable, tags, created) VALUES`)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		var nullable any
+		if i%2 == 1 {
+			nullable = i
+		}
+		_, err = stmt.Exec(i, "name", nullable, []string{"a", "b"}, created)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tx.Commit())
+	require.NoError(t, stmt.Close())
+
+	// insert without transaction
+	_, err = db.Exec(`INSERT INTO test_stdlib (id, name, nullable, tags, created) VALUES`,
+		uint64(10), "single", nil, []string{}, created)
+	require.NoError(t, err)
+
+	rows, err := db.Query(`SELECT id, name, nullable, tags, created FROM test_stdlib
+		WHERE id >= {min_id:UInt64} ORDER BY id`, sql.Named("min_id", 5))
+	require.NoError(t, err)
+	columnTypes, err := rows.ColumnTypes()
+	require.NoError(t, err)
+	assert.Equal(t, "Nullable(Int32)", columnTypes[2].DatabaseTypeName())
+
+	var ids []uint64
+	for rows.Next() {
+		var (
+			id       uint64
+			name     string
+			nullable sql.NullInt32
+			tags     any
+			date     time.Time
+		)
+		require.NoError(t, rows.Scan(&id, &name, &nullable, &tags, &date))
+		ids = append(ids, id)
+		assert.Equal(t, id%2 == 1 && id < 10, nullable.Valid)
+		assert.True(t, created.Equal(date))
+		if id < 10 {
+			assert.Equal(t, "name", name)
+			assert.Equal(t, []string{"a", "b"}, tags)
+		}
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []uint64{5, 6, 7, 8, 9, 10}, ids)
+
+	// rollback drop the rows
+	tx, err = db.Begin()
+	require.NoError(t, err)
+	_, err = tx.Exec(`INSERT INTO test_stdlib (id, name, nullable, tags, created) VALUES`,
+		uint64(11), "rollback", nil, []string{}, created)
+	require.NoError(t, err)
+	require.NoError(t, tx.Rollback())
+
+	var count uint64
+	require.NoError(t, db.QueryRow(`SELECT count() FROM test_stdlib`).Scan(&count))
+	assert.Equal(t, uint64(11), count)
+
+	_, err = db.Query(`SELECT 1 WHERE 1 = ?`, 1)
+	require.EqualError(t, err, "chconn/stdlib: positional argument 1 is not supported, use sql.Named")
+}
+
+func TestSQLFromPool(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	pool, err := chpool.New(connString)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	db := OpenDBFromPool(pool)
+	defer db.Close()
+
+	require.NoError(t, db.PingContext(context.Background()))
+
+	var (
+		number uint64
+		str    string
+	)
+	err = db.QueryRow(`SELECT number, {str:String} FROM system.numbers LIMIT 1 OFFSET 5`,
+		sql.Named("str", "it's")).Scan(&number, &str)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), number)
+	assert.Equal(t, "it's", str)
+}
@@ -0,0 +1,147 @@
+package chconn
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TableColumn is the description of a column of the table that the server sends for insert queries.
+type TableColumn struct {
+	Name string
+	Type string
+	// DefaultKind is the kind of the default expression (DEFAULT, MATERIALIZED, ALIAS or EPHEMERAL).
+	// It is empty if the column does not have a default expression.
+	DefaultKind       string
+	DefaultExpression string
+	Comment           string
+	Codec             string
+	TTL               string
+}
+
+// HasDefault reports if the server can compute the value of the column when it is omitted from the insert query.
+func (c *TableColumn) HasDefault() bool {
+	return c.DefaultKind != ""
+}
+
+// canOmit reports if the column can be omitted from the insert query. the nullable columns are filled with NULL.
+func (c *TableColumn) canOmit() bool {
+	return c.HasDefault() ||
+		strings.HasPrefix(c.Type, "Nullable(") ||
+		strings.HasPrefix(c.Type, "LowCardinality(Nullable(")
+}
+
+const tableColumnsFormatVersion = "columns format version: 1"
+
+// parseTableColumns parse the columns description that the server sends in the TableColumns packet.
+//
+//	columns format version: 1
+//	2 columns:
+//	`id` UInt64
+//	`name` String	DEFAULT	'unknown'	CODEC(ZSTD(1))
+func parseTableColumns(desc string) ([]TableColumn, error) {
+	lines := strings.Split(desc, "\n")
+	if len(lines) < 2 || lines[0] != tableColumnsFormatVersion {
+		return nil, &tableColumnsError{msg: "unknown format version"}
+	}
+	count, err := strconv.Atoi(strings.TrimSuffix(lines[1], " columns:"))
+	if err != nil {
+		return nil, &tableColumnsError{msg: "invalid number of columns", err: err}
+	}
+	columns := make([]TableColumn, 0, count)
+	for _, line := range lines[2:] {
+		if line == "" {
+			continue
+		}
+		col, err := parseTableColumn(line)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, col)
+	}
+	if len(columns) != count {
+		return nil, &tableColumnsError{msg: fmt.Sprintf("expected %d columns but got %d", count, len(columns))}
+	}
+	return columns, nil
+}
+
+func parseTableColumn(line string) (TableColumn, error) {
+	var col TableColumn
+	if len(line) == 0 || line[0] != '`' {
+		return col, &tableColumnsError{msg: fmt.Sprintf("invalid column %q", line)}
+	}
+	end := 1
+	for ; end < len(line) && line[end] != '`'; end++ {
+		if line[end] == '\\' {
+			end++
+		}
+	}
+	if end+1 >= len(line) || line[end+1] != ' ' {
+		return col, &tableColumnsError{msg: fmt.Sprintf("invalid column %q", line)}
+	}
+	col.Name = unescapeString(line[1:end])
+
+	fields := strings.Split(line[end+2:], "\t")
+	col.Type = unescapeString(fields[0])
+	for i := 1; i < len(fields); i++ {
+		field := fields[i]
+		switch {
+		case field == "DEFAULT" || field == "MATERIALIZED" || field == "ALIAS" || field == "EPHEMERAL":
+			col.DefaultKind = field
+			if i+1 < len(fields) {
+				i++
+				col.DefaultExpression = unescapeString(fields[i])
+			}
+		case strings.HasPrefix(field, "COMMENT "):
+			comment := unescapeString(strings.TrimPrefix(field, "COMMENT "))
+			col.Comment = unescapeString(strings.TrimSuffix(strings.TrimPrefix(comment, "'"), "'"))
+		case strings.HasPrefix(field, "CODEC("):
+			col.Codec = unescapeString(field)
+		case strings.HasPrefix(field, "TTL "):
+			col.TTL = unescapeString(strings.TrimPrefix(field, "TTL "))
+		}
+	}
+	return col, nil
+}
+
+// unescapeString reverse the escaping of ClickHouse `writeEscapedString`
+func unescapeString(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'b':
+			sb.WriteByte('\b')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		case '0':
+			sb.WriteByte(0)
+		case 'x':
+			if i+2 < len(s) {
+				if v, err := strconv.ParseUint(s[i+1:i+3], 16, 8); err == nil {
+					sb.WriteByte(byte(v))
+					i += 2
+					continue
+				}
+			}
+			sb.WriteByte(s[i])
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String()
+}
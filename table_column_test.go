@@ -0,0 +1,112 @@
+package chconn
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+)
+
+func TestParseTableColumns(t *testing.T) {
+	desc := "columns format version: 1\n" +
+		"5 columns:\n" +
+		"`id` UInt64\n" +
+		"`name` String\tDEFAULT\t\\'unknown\\'\tCODEC(ZSTD(1))\n" +
+		"`id2` UInt64\tMATERIALIZED\tid * 2\tCOMMENT \\'it\\\\\\'s\\ttab\\'\n" +
+		"`a\\`b` Tuple(a String, b Int8)\tALIAS\tname\n" +
+		"`d` DateTime\tTTL d + toIntervalDay(1)\n"
+
+	columns, err := parseTableColumns(desc)
+	require.NoError(t, err)
+	assert.Equal(t, []TableColumn{
+		{Name: "id", Type: "UInt64"},
+		{Name: "name", Type: "String", DefaultKind: "DEFAULT", DefaultExpression: "'unknown'", Codec: "CODEC(ZSTD(1))"},
+		{Name: "id2", Type: "UInt64", DefaultKind: "MATERIALIZED", DefaultExpression: "id * 2", Comment: "it's\ttab"},
+		{Name: "a`b", Type: "Tuple(a String, b Int8)", DefaultKind: "ALIAS", DefaultExpression: "name"},
+		{Name: "d", Type: "DateTime", TTL: "d + toIntervalDay(1)"},
+	}, columns)
+	assert.False(t, columns[0].HasDefault())
+	assert.True(t, columns[1].HasDefault())
+	assert.False(t, columns[0].canOmit())
+	assert.True(t, columns[1].canOmit())
+	assert.True(t, (&TableColumn{Type: "Nullable(String)"}).canOmit())
+	assert.True(t, (&TableColumn{Type: "LowCardinality(Nullable(String))"}).canOmit())
+	assert.False(t, (&TableColumn{Type: "LowCardinality(String)"}).canOmit())
+
+	_, err = parseTableColumns("columns format version: 2\n0 columns:\n")
+	require.EqualError(t, err, "table columns: unknown format version")
+	_, err = parseTableColumns("columns format version: 1\nx columns:\n")
+	require.EqualError(t, err, "table columns: invalid number of columns (strconv.Atoi: parsing \"x\": invalid syntax)")
+	_, err = parseTableColumns("columns format version: 1\n2 columns:\n`id` UInt64\n")
+	require.EqualError(t, err, "table columns: expected 2 columns but got 1")
+	_, err = parseTableColumns("columns format version: 1\n1 columns:\nid UInt64\n")
+	require.EqualError(t, err, "table columns: invalid column \"id UInt64\"")
+}
+
+func TestInsertTableColumns(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	c, err := Connect(context.Background(), connString)
+	require.NoError(t, err)
+
+	err = c.Exec(context.Background(), `DROP TABLE IF EXISTS test_insert_table_columns`)
+	require.NoError(t, err)
+	err = c.Exec(context.Background(), `CREATE TABLE test_insert_table_columns (
+				id UInt64,
+				name String DEFAULT 'unknown' CODEC(ZSTD(1)),
+				id2 UInt64 MATERIALIZED id * 2,
+				note Nullable(String)
+			) Engine=Memory`)
+	require.NoError(t, err)
+
+	validateOptions := &QueryOptions{
+		ValidateOmittedColumns: true,
+	}
+	// the omitted nullable column is filled with NULL
+	stmt, err := c.InsertStreamWithOption(context.Background(), `INSERT INTO test_insert_table_columns (id) VALUES`,
+		validateOptions)
+	require.NoError(t, err)
+	tableColumns := stmt.TableColumns()
+	require.Len(t, tableColumns, 4)
+	assert.Equal(t, "name", tableColumns[1].Name)
+	assert.Equal(t, "DEFAULT", tableColumns[1].DefaultKind)
+	assert.Equal(t, "'unknown'", tableColumns[1].DefaultExpression)
+	assert.Equal(t, "CODEC(ZSTD(1))", tableColumns[1].Codec)
+	assert.Equal(t, "MATERIALIZED", tableColumns[2].DefaultKind)
+
+	colID := column.New[uint64]()
+	colID.Append(1, 2)
+	require.NoError(t, stmt.Write(context.Background(), colID))
+	require.NoError(t, stmt.Flush(context.Background()))
+
+	colName := column.NewString()
+	selectStmt, err := c.Select(context.Background(), `SELECT name FROM test_insert_table_columns`, colName)
+	require.NoError(t, err)
+	var names []string
+	for selectStmt.Next() {
+		names = colName.Read(names)
+	}
+	require.NoError(t, selectStmt.Err())
+	assert.Equal(t, []string{"unknown", "unknown"}, names)
+
+	// omit a column without default expression
+	_, err = c.InsertStreamWithOption(context.Background(), `INSERT INTO test_insert_table_columns (name) VALUES`,
+		validateOptions)
+	var notSuppliedErr *ColumnNotSuppliedError
+	require.True(t, errors.As(err, &notSuppliedErr))
+	assert.Equal(t, "id", notSuppliedErr.Column)
+	assert.False(t, c.IsClosed())
+	require.NoError(t, c.Ping(context.Background()))
+
+	// the omitted columns are not validated by default
+	stmt, err = c.InsertStream(context.Background(), `INSERT INTO test_insert_table_columns (name) VALUES`)
+	require.NoError(t, err)
+	require.NoError(t, stmt.Flush(context.Background()))
+	c.Close()
+}
@@ -0,0 +1,121 @@
+package chconn
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// TraceContext is the W3C trace context (https://www.w3.org/TR/trace-context/) of a query.
+// It is sent to the server in the client info, so the spans of the query in `system.opentelemetry_span_log`
+// are the children of the client span.
+type TraceContext struct {
+	TraceID    [16]byte
+	SpanID     [8]byte
+	TraceState string
+	TraceFlags uint8
+}
+
+var errInvalidTraceParent = errors.New("invalid traceparent")
+
+// ParseTraceParent parses the W3C traceparent (e.g. 00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01)
+// and the tracestate headers.
+func ParseTraceParent(traceParent, traceState string) (*TraceContext, error) {
+	parts := strings.Split(strings.TrimSpace(traceParent), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" ||
+		len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return nil, errInvalidTraceParent
+	}
+	// the version 00 has exactly four parts. the future versions may add more parts.
+	if parts[0] == "00" && len(parts) != 4 {
+		return nil, errInvalidTraceParent
+	}
+	tc := &TraceContext{
+		TraceState: traceState,
+	}
+	var flags [1]byte
+	if _, err := hex.Decode(tc.TraceID[:], []byte(parts[1])); err != nil {
+		return nil, errInvalidTraceParent
+	}
+	if _, err := hex.Decode(tc.SpanID[:], []byte(parts[2])); err != nil {
+		return nil, errInvalidTraceParent
+	}
+	if _, err := hex.Decode(flags[:], []byte(parts[3])); err != nil {
+		return nil, errInvalidTraceParent
+	}
+	tc.TraceFlags = flags[0]
+	if !tc.IsValid() {
+		return nil, errInvalidTraceParent
+	}
+	return tc, nil
+}
+
+// IsValid reports if the trace id and the span id are not zero.
+func (tc *TraceContext) IsValid() bool {
+	return tc != nil && tc.TraceID != [16]byte{} && tc.SpanID != [8]byte{}
+}
+
+// TraceParent returns the W3C traceparent of the trace context.
+func (tc *TraceContext) TraceParent() string {
+	return "00-" + hex.EncodeToString(tc.TraceID[:]) + "-" +
+		hex.EncodeToString(tc.SpanID[:]) + "-" + hex.EncodeToString([]byte{tc.TraceFlags})
+}
+
+func (tc *TraceContext) write(ch *conn) {
+	if !tc.IsValid() {
+		ch.writer.Uint8(0)
+		return
+	}
+	ch.writer.Uint8(1)
+	ch.writer.Uint64(binary.BigEndian.Uint64(tc.TraceID[:8]))
+	ch.writer.Uint64(binary.BigEndian.Uint64(tc.TraceID[8:]))
+	ch.writer.Uint64(binary.BigEndian.Uint64(tc.SpanID[:]))
+	ch.writer.String(tc.TraceState)
+	ch.writer.Uint8(tc.TraceFlags)
+}
+
+type traceContextKey struct{}
+
+// ContextWithTraceContext returns a copy of ctx with the trace context.
+// The queries that are run with the returned context send the trace context to the server,
+// unless QueryOptions.TraceContext is set.
+func ContextWithTraceContext(ctx context.Context, tc *TraceContext) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, tc)
+}
+
+// TraceContextFromContext returns the trace context of ctx set by ContextWithTraceContext or nil.
+func TraceContextFromContext(ctx context.Context) *TraceContext {
+	tc, _ := ctx.Value(traceContextKey{}).(*TraceContext)
+	return tc
+}
+
+// StartSpanFunc is a function that starts a client span of a query. operation is "Exec", "Select" or "Insert".
+// The returned context is used for the query. So the span can be sent to the server by
+// putting its trace context in it (see ContextWithTraceContext).
+// The returned function is called with the error of the query (nil on success) when the query is finished.
+// For select and insert statements it is called on Close.
+type StartSpanFunc func(ctx context.Context, operation, query string) (context.Context, func(err error))
+
+func noopEndSpan(error) {}
+
+// startSpan starts the client span of the query if Config.StartSpan is set.
+func (ch *conn) startSpan(ctx context.Context, operation, query string) (context.Context, func(error)) {
+	if ch.config.StartSpan == nil {
+		return ctx, noopEndSpan
+	}
+	ctx, end := ch.config.StartSpan(ctx, operation, query)
+	if end == nil {
+		end = noopEndSpan
+	}
+	return ctx, end
+}
+
+// traceContext returns the trace context of the query. QueryOptions.TraceContext has priority over the context.
+func (o *QueryOptions) traceContext(ctx context.Context) *TraceContext {
+	if o.TraceContext != nil {
+		return o.TraceContext
+	}
+	return TraceContextFromContext(ctx)
+}
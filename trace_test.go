@@ -0,0 +1,101 @@
+package chconn
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vahid-sohrabloo/chconn/v2/column"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	t.Parallel()
+
+	traceParent := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	tc, err := ParseTraceParent(traceParent, "congo=t61rcWkgMzE")
+	require.NoError(t, err)
+	assert.Equal(t, byte(0x0a), tc.TraceID[0])
+	assert.Equal(t, byte(0x9c), tc.TraceID[15])
+	assert.Equal(t, byte(0xb7), tc.SpanID[0])
+	assert.Equal(t, uint8(1), tc.TraceFlags)
+	assert.Equal(t, "congo=t61rcWkgMzE", tc.TraceState)
+	assert.Equal(t, traceParent, tc.TraceParent())
+
+	for _, invalid := range []string{
+		"",
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331",
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01-00",
+		"ff-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"00-00000000000000000000000000000000-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd8448eb211c80319c-0000000000000000-01",
+		"00-0af7651916cd43dd8448eb211c80319x-b7ad6b7169203331-01",
+	} {
+		_, err := ParseTraceParent(invalid, "")
+		assert.ErrorIs(t, err, errInvalidTraceParent, invalid)
+	}
+
+	ctx := ContextWithTraceContext(context.Background(), tc)
+	assert.Equal(t, tc, TraceContextFromContext(ctx))
+	assert.Nil(t, TraceContextFromContext(context.Background()))
+	assert.False(t, (*TraceContext)(nil).IsValid())
+}
+
+func TestTraceContext(t *testing.T) {
+	t.Parallel()
+
+	connString := os.Getenv("CHX_TEST_TCP_CONN_STRING")
+
+	config, err := ParseConfig(connString)
+	require.NoError(t, err)
+
+	tc, err := ParseTraceParent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", "")
+	require.NoError(t, err)
+
+	var spans []string
+	var spanErrs []error
+	config.StartSpan = func(ctx context.Context, operation, query string) (context.Context, func(error)) {
+		spans = append(spans, operation)
+		return ContextWithTraceContext(ctx, tc), func(err error) {
+			spanErrs = append(spanErrs, err)
+		}
+	}
+
+	c, err := ConnectConfig(context.Background(), config)
+	require.NoError(t, err)
+	defer c.Close()
+
+	err = c.Exec(context.Background(), `DROP TABLE IF EXISTS test_trace_context`)
+	require.NoError(t, err)
+	err = c.Exec(context.Background(), `CREATE TABLE test_trace_context (id UInt64) Engine=Memory`)
+	require.NoError(t, err)
+
+	col := column.New[uint64]()
+	col.Append(1, 2, 3)
+	err = c.Insert(context.Background(), `INSERT INTO test_trace_context (id) VALUES`, col)
+	require.NoError(t, err)
+
+	colRead := column.New[uint64]()
+	stmt, err := c.SelectWithOption(context.Background(), `SELECT id FROM test_trace_context`, &QueryOptions{
+		TraceContext: tc,
+	}, colRead)
+	require.NoError(t, err)
+	var ids []uint64
+	for stmt.Next() {
+		ids = colRead.Read(ids)
+	}
+	require.NoError(t, stmt.Err())
+	stmt.Close()
+	assert.Equal(t, []uint64{1, 2, 3}, ids)
+
+	err = c.Exec(context.Background(), `SELECT * FROM not_found_table`)
+	require.Error(t, err)
+
+	assert.Equal(t, []string{OperationExec, OperationExec, OperationInsert, OperationSelect, OperationExec}, spans)
+	require.Len(t, spanErrs, 5)
+	for _, err := range spanErrs[:4] {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, err, spanErrs[4])
+}
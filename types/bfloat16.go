@@ -0,0 +1,23 @@
+package types
+
+import "math"
+
+// BFloat16 is the raw value of BFloat16 ClickHouse data type (brain floating point).
+// It is the upper 16 bits of a float32.
+type BFloat16 uint16
+
+// BFloat16FromFloat32 converts float32 to bfloat16 (rounding to nearest even).
+func BFloat16FromFloat32(f float32) BFloat16 {
+	bits := math.Float32bits(f)
+	if f != f {
+		// keep NaN as a quiet NaN
+		return BFloat16(bits>>16 | 0x40)
+	}
+	bits += 0x7fff + (bits>>16)&1
+	return BFloat16(bits >> 16)
+}
+
+// Float32 converts bfloat16 to float32.
+func (b BFloat16) Float32() float32 {
+	return math.Float32frombits(uint32(b) << 16)
+}
@@ -0,0 +1,21 @@
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBFloat16(t *testing.T) {
+	assert.Equal(t, BFloat16(0x3f80), BFloat16FromFloat32(1))
+	assert.Equal(t, float32(1), BFloat16(0x3f80).Float32())
+	assert.Equal(t, float32(-2.5), BFloat16FromFloat32(-2.5).Float32())
+	// 1 + 2^-8 is the halfway between two bfloat16 values and rounds to even
+	assert.Equal(t, float32(1), BFloat16FromFloat32(1+1.0/256).Float32())
+	assert.Equal(t, float32(1+1.0/64), BFloat16FromFloat32(1+3.0/256).Float32())
+	assert.Equal(t, float32(3.140625), BFloat16FromFloat32(math.Pi).Float32())
+	assert.True(t, math.IsInf(float64(BFloat16FromFloat32(math.MaxFloat32).Float32()), 1))
+	nan := BFloat16FromFloat32(float32(math.NaN())).Float32()
+	assert.True(t, nan != nan)
+}
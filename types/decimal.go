@@ -1,5 +1,12 @@
 package types
 
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
 // Decimal32 represents a 32-bit decimal number.
 type Decimal32 int32
 
@@ -12,6 +19,12 @@ type Decimal128 Int128
 // Decimal256 represents a 256-bit decimal number.
 type Decimal256 Int256
 
+// ErrDecimalOverflow is returned when the result does not fit in the decimal type
+var ErrDecimalOverflow = errors.New("decimal overflow")
+
+// ErrDecimalDivisionByZero is returned on division by zero
+var ErrDecimalDivisionByZero = errors.New("decimal division by zero")
+
 // Table of powers of 10 for fast casting from floating types to decimal type
 // representations.
 var factors10 = []float64{
@@ -38,3 +51,410 @@ func Decimal32FromFloat64(f float64, scale int) Decimal32 {
 func Decimal64FromFloat64(f float64, scale int) Decimal64 {
 	return Decimal64(f * factors10[scale])
 }
+
+// String converts decimal number to the exact string representation. e.g. 12.340 for 12340 with scale 3
+func (d Decimal32) String(scale int) string {
+	return decimalString(d.Big(), scale)
+}
+
+// String converts decimal number to the exact string representation. e.g. 12.340 for 12340 with scale 3
+func (d Decimal64) String(scale int) string {
+	return decimalString(d.Big(), scale)
+}
+
+// String converts decimal number to the exact string representation. e.g. 12.340 for 12340 with scale 3
+func (d Decimal128) String(scale int) string {
+	return decimalString(d.Big(), scale)
+}
+
+// String converts decimal number to the exact string representation. e.g. 12.340 for 12340 with scale 3
+func (d Decimal256) String(scale int) string {
+	return decimalString(d.Big(), scale)
+}
+
+// ParseDecimal32 parses the string representation of a decimal number with the given scale.
+// It returns an error if the number has more fractional digits than the scale or does not fit.
+func ParseDecimal32(s string, scale int) (Decimal32, error) {
+	i, err := parseDecimal(s, scale)
+	if err != nil {
+		return 0, err
+	}
+	return Decimal32FromBig(i)
+}
+
+// ParseDecimal64 parses the string representation of a decimal number with the given scale.
+// It returns an error if the number has more fractional digits than the scale or does not fit.
+func ParseDecimal64(s string, scale int) (Decimal64, error) {
+	i, err := parseDecimal(s, scale)
+	if err != nil {
+		return 0, err
+	}
+	return Decimal64FromBig(i)
+}
+
+// ParseDecimal128 parses the string representation of a decimal number with the given scale.
+// It returns an error if the number has more fractional digits than the scale or does not fit.
+func ParseDecimal128(s string, scale int) (Decimal128, error) {
+	i, err := parseDecimal(s, scale)
+	if err != nil {
+		return Decimal128{}, err
+	}
+	return Decimal128FromBig(i)
+}
+
+// ParseDecimal256 parses the string representation of a decimal number with the given scale.
+// It returns an error if the number has more fractional digits than the scale or does not fit.
+func ParseDecimal256(s string, scale int) (Decimal256, error) {
+	i, err := parseDecimal(s, scale)
+	if err != nil {
+		return Decimal256{}, err
+	}
+	return Decimal256FromBig(i)
+}
+
+// Big returns the unscaled value as a *big.Int.
+func (d Decimal32) Big() *big.Int {
+	return big.NewInt(int64(d))
+}
+
+// Big returns the unscaled value as a *big.Int.
+func (d Decimal64) Big() *big.Int {
+	return big.NewInt(int64(d))
+}
+
+// Big returns the unscaled value as a *big.Int.
+func (d Decimal128) Big() *big.Int {
+	return Int128(d).Big()
+}
+
+// Big returns the unscaled value as a *big.Int.
+func (d Decimal256) Big() *big.Int {
+	return Int256(d).Big()
+}
+
+// Decimal32FromBig converts the unscaled value to decimal32 number.
+func Decimal32FromBig(i *big.Int) (Decimal32, error) {
+	if !fitsBits(i, 32) {
+		return 0, ErrDecimalOverflow
+	}
+	return Decimal32(i.Int64()), nil
+}
+
+// Decimal64FromBig converts the unscaled value to decimal64 number.
+func Decimal64FromBig(i *big.Int) (Decimal64, error) {
+	if !fitsBits(i, 64) {
+		return 0, ErrDecimalOverflow
+	}
+	return Decimal64(i.Int64()), nil
+}
+
+// Decimal128FromBig converts the unscaled value to decimal128 number.
+func Decimal128FromBig(i *big.Int) (Decimal128, error) {
+	if !fitsBits(i, 128) {
+		return Decimal128{}, ErrDecimalOverflow
+	}
+	w := twosComplementWords(i, 2)
+	return Decimal128{Lo: w[0], Hi: int64(w[1])}, nil
+}
+
+// Decimal256FromBig converts the unscaled value to decimal256 number.
+func Decimal256FromBig(i *big.Int) (Decimal256, error) {
+	if !fitsBits(i, 256) {
+		return Decimal256{}, ErrDecimalOverflow
+	}
+	w := twosComplementWords(i, 4)
+	return Decimal256{
+		Lo: Uint128{Lo: w[0], Hi: w[1]},
+		Hi: Int128{Lo: w[2], Hi: int64(w[3])},
+	}, nil
+}
+
+// Rat returns the exact value as a *big.Rat.
+func (d Decimal32) Rat(scale int) *big.Rat {
+	return new(big.Rat).SetFrac(d.Big(), pow10(scale))
+}
+
+// Rat returns the exact value as a *big.Rat.
+func (d Decimal64) Rat(scale int) *big.Rat {
+	return new(big.Rat).SetFrac(d.Big(), pow10(scale))
+}
+
+// Rat returns the exact value as a *big.Rat.
+func (d Decimal128) Rat(scale int) *big.Rat {
+	return new(big.Rat).SetFrac(d.Big(), pow10(scale))
+}
+
+// Rat returns the exact value as a *big.Rat.
+func (d Decimal256) Rat(scale int) *big.Rat {
+	return new(big.Rat).SetFrac(d.Big(), pow10(scale))
+}
+
+// Decimal32FromRat converts *big.Rat to decimal32 number. the extra fractional digits are truncated.
+func Decimal32FromRat(r *big.Rat, scale int) (Decimal32, error) {
+	return Decimal32FromBig(ratToBig(r, scale))
+}
+
+// Decimal64FromRat converts *big.Rat to decimal64 number. the extra fractional digits are truncated.
+func Decimal64FromRat(r *big.Rat, scale int) (Decimal64, error) {
+	return Decimal64FromBig(ratToBig(r, scale))
+}
+
+// Decimal128FromRat converts *big.Rat to decimal128 number. the extra fractional digits are truncated.
+func Decimal128FromRat(r *big.Rat, scale int) (Decimal128, error) {
+	return Decimal128FromBig(ratToBig(r, scale))
+}
+
+// Decimal256FromRat converts *big.Rat to decimal256 number. the extra fractional digits are truncated.
+func Decimal256FromRat(r *big.Rat, scale int) (Decimal256, error) {
+	return Decimal256FromBig(ratToBig(r, scale))
+}
+
+// Cmp compares d and v (with the same scale) and returns -1, 0 or +1.
+func (d Decimal32) Cmp(v Decimal32) int {
+	return d.Big().Cmp(v.Big())
+}
+
+// Cmp compares d and v (with the same scale) and returns -1, 0 or +1.
+func (d Decimal64) Cmp(v Decimal64) int {
+	return d.Big().Cmp(v.Big())
+}
+
+// Cmp compares d and v (with the same scale) and returns -1, 0 or +1.
+func (d Decimal128) Cmp(v Decimal128) int {
+	return d.Big().Cmp(v.Big())
+}
+
+// Cmp compares d and v (with the same scale) and returns -1, 0 or +1.
+func (d Decimal256) Cmp(v Decimal256) int {
+	return d.Big().Cmp(v.Big())
+}
+
+// Add returns d+v. d and v must have the same scale.
+func (d Decimal32) Add(v Decimal32) (Decimal32, error) {
+	return Decimal32FromBig(new(big.Int).Add(d.Big(), v.Big()))
+}
+
+// Add returns d+v. d and v must have the same scale.
+func (d Decimal64) Add(v Decimal64) (Decimal64, error) {
+	return Decimal64FromBig(new(big.Int).Add(d.Big(), v.Big()))
+}
+
+// Add returns d+v. d and v must have the same scale.
+func (d Decimal128) Add(v Decimal128) (Decimal128, error) {
+	return Decimal128FromBig(new(big.Int).Add(d.Big(), v.Big()))
+}
+
+// Add returns d+v. d and v must have the same scale.
+func (d Decimal256) Add(v Decimal256) (Decimal256, error) {
+	return Decimal256FromBig(new(big.Int).Add(d.Big(), v.Big()))
+}
+
+// Sub returns d-v. d and v must have the same scale.
+func (d Decimal32) Sub(v Decimal32) (Decimal32, error) {
+	return Decimal32FromBig(new(big.Int).Sub(d.Big(), v.Big()))
+}
+
+// Sub returns d-v. d and v must have the same scale.
+func (d Decimal64) Sub(v Decimal64) (Decimal64, error) {
+	return Decimal64FromBig(new(big.Int).Sub(d.Big(), v.Big()))
+}
+
+// Sub returns d-v. d and v must have the same scale.
+func (d Decimal128) Sub(v Decimal128) (Decimal128, error) {
+	return Decimal128FromBig(new(big.Int).Sub(d.Big(), v.Big()))
+}
+
+// Sub returns d-v. d and v must have the same scale.
+func (d Decimal256) Sub(v Decimal256) (Decimal256, error) {
+	return Decimal256FromBig(new(big.Int).Sub(d.Big(), v.Big()))
+}
+
+// Mul returns d*v with the same scale as d and v. the extra fractional digits are truncated.
+func (d Decimal32) Mul(v Decimal32, scale int) (Decimal32, error) {
+	return Decimal32FromBig(decimalMul(d.Big(), v.Big(), scale))
+}
+
+// Mul returns d*v with the same scale as d and v. the extra fractional digits are truncated.
+func (d Decimal64) Mul(v Decimal64, scale int) (Decimal64, error) {
+	return Decimal64FromBig(decimalMul(d.Big(), v.Big(), scale))
+}
+
+// Mul returns d*v with the same scale as d and v. the extra fractional digits are truncated.
+func (d Decimal128) Mul(v Decimal128, scale int) (Decimal128, error) {
+	return Decimal128FromBig(decimalMul(d.Big(), v.Big(), scale))
+}
+
+// Mul returns d*v with the same scale as d and v. the extra fractional digits are truncated.
+func (d Decimal256) Mul(v Decimal256, scale int) (Decimal256, error) {
+	return Decimal256FromBig(decimalMul(d.Big(), v.Big(), scale))
+}
+
+// Div returns d/v with the same scale as d and v. the extra fractional digits are truncated.
+func (d Decimal32) Div(v Decimal32, scale int) (Decimal32, error) {
+	i, err := decimalDiv(d.Big(), v.Big(), scale)
+	if err != nil {
+		return 0, err
+	}
+	return Decimal32FromBig(i)
+}
+
+// Div returns d/v with the same scale as d and v. the extra fractional digits are truncated.
+func (d Decimal64) Div(v Decimal64, scale int) (Decimal64, error) {
+	i, err := decimalDiv(d.Big(), v.Big(), scale)
+	if err != nil {
+		return 0, err
+	}
+	return Decimal64FromBig(i)
+}
+
+// Div returns d/v with the same scale as d and v. the extra fractional digits are truncated.
+func (d Decimal128) Div(v Decimal128, scale int) (Decimal128, error) {
+	i, err := decimalDiv(d.Big(), v.Big(), scale)
+	if err != nil {
+		return Decimal128{}, err
+	}
+	return Decimal128FromBig(i)
+}
+
+// Div returns d/v with the same scale as d and v. the extra fractional digits are truncated.
+func (d Decimal256) Div(v Decimal256, scale int) (Decimal256, error) {
+	i, err := decimalDiv(d.Big(), v.Big(), scale)
+	if err != nil {
+		return Decimal256{}, err
+	}
+	return Decimal256FromBig(i)
+}
+
+// Rescale converts the decimal number from a scale to another. the extra fractional digits are truncated.
+func (d Decimal32) Rescale(from, to int) (Decimal32, error) {
+	return Decimal32FromBig(rescale(d.Big(), from, to))
+}
+
+// Rescale converts the decimal number from a scale to another. the extra fractional digits are truncated.
+func (d Decimal64) Rescale(from, to int) (Decimal64, error) {
+	return Decimal64FromBig(rescale(d.Big(), from, to))
+}
+
+// Rescale converts the decimal number from a scale to another. the extra fractional digits are truncated.
+func (d Decimal128) Rescale(from, to int) (Decimal128, error) {
+	return Decimal128FromBig(rescale(d.Big(), from, to))
+}
+
+// Rescale converts the decimal number from a scale to another. the extra fractional digits are truncated.
+func (d Decimal256) Rescale(from, to int) (Decimal256, error) {
+	return Decimal256FromBig(rescale(d.Big(), from, to))
+}
+
+func pow10(scale int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+}
+
+// fitsBits checks the value fits in a signed integer of the given bits
+func fitsBits(i *big.Int, bits int) bool {
+	if i.Sign() >= 0 {
+		return i.BitLen() < bits
+	}
+	// -2^(bits-1) is the minimum value
+	abs := new(big.Int).Neg(i)
+	return abs.BitLen() < bits || (abs.BitLen() == bits && abs.TrailingZeroBits() == uint(bits-1))
+}
+
+// twosComplementWords return the 64-bit words (low word first) of the two's complement representation
+func twosComplementWords(i *big.Int, n int) []uint64 {
+	v := i
+	if i.Sign() < 0 {
+		v = new(big.Int).Lsh(big.NewInt(1), uint(n*64))
+		v.Add(v, i)
+	}
+	mask := new(big.Int).SetUint64(^uint64(0))
+	t := new(big.Int)
+	words := make([]uint64, n)
+	for j := range words {
+		words[j] = t.And(t.Rsh(v, uint(j*64)), mask).Uint64()
+	}
+	return words
+}
+
+func decimalString(i *big.Int, scale int) string {
+	s := i.String()
+	if scale <= 0 {
+		return s
+	}
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+	if len(s) <= scale {
+		s = strings.Repeat("0", scale-len(s)+1) + s
+	}
+	s = s[:len(s)-scale] + "." + s[len(s)-scale:]
+	if neg {
+		return "-" + s
+	}
+	return s
+}
+
+func parseDecimal(s string, scale int) (*big.Int, error) {
+	if scale < 0 {
+		return nil, fmt.Errorf("invalid decimal scale %d", scale)
+	}
+	str := s
+	neg := false
+	if str != "" && (str[0] == '-' || str[0] == '+') {
+		neg = str[0] == '-'
+		str = str[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(str, ".")
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return nil, fmt.Errorf("invalid decimal %q", s)
+	}
+	if trimmed := strings.TrimRight(fracPart, "0"); len(trimmed) > scale {
+		return nil, fmt.Errorf("invalid decimal %q: more than %d fractional digits", s, scale)
+	}
+	if len(fracPart) > scale {
+		fracPart = fracPart[:scale]
+	}
+	digits := intPart + fracPart + strings.Repeat("0", scale-len(fracPart))
+	i, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal %q", s)
+	}
+	if neg {
+		i.Neg(i)
+	}
+	return i, nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func ratToBig(r *big.Rat, scale int) *big.Int {
+	num := new(big.Int).Mul(r.Num(), pow10(scale))
+	return num.Quo(num, r.Denom())
+}
+
+func decimalMul(a, b *big.Int, scale int) *big.Int {
+	i := new(big.Int).Mul(a, b)
+	return i.Quo(i, pow10(scale))
+}
+
+func decimalDiv(a, b *big.Int, scale int) (*big.Int, error) {
+	if b.Sign() == 0 {
+		return nil, ErrDecimalDivisionByZero
+	}
+	i := new(big.Int).Mul(a, pow10(scale))
+	return i.Quo(i, b), nil
+}
+
+func rescale(i *big.Int, from, to int) *big.Int {
+	if to >= from {
+		return new(big.Int).Mul(i, pow10(to-from))
+	}
+	return new(big.Int).Quo(i, pow10(from-to))
+}
@@ -1,9 +1,12 @@
 package types
 
 import (
+	"math"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDecimal(t *testing.T) {
@@ -14,3 +17,110 @@ func TestDecimal(t *testing.T) {
 	assert.Equal(t, Decimal32FromFloat64(12.2334, 3), Decimal32(12233))
 	assert.Equal(t, Decimal64FromFloat64(12.2334, 3), Decimal64(12233))
 }
+
+func TestDecimalString(t *testing.T) {
+	assert.Equal(t, "12.234", Decimal32(12_234).String(3))
+	assert.Equal(t, "-0.005", Decimal64(-5).String(3))
+	assert.Equal(t, "42", Decimal64(42).String(0))
+	assert.Equal(t, "0.10", Decimal32(10).String(2))
+
+	d32, err := ParseDecimal32("-12.23", 3)
+	require.NoError(t, err)
+	assert.Equal(t, Decimal32(-12_230), d32)
+	d64, err := ParseDecimal64(".5", 2)
+	require.NoError(t, err)
+	assert.Equal(t, Decimal64(50), d64)
+	d64, err = ParseDecimal64("+7.100", 1)
+	require.NoError(t, err)
+	assert.Equal(t, Decimal64(71), d64)
+
+	_, err = ParseDecimal32("1.234", 2)
+	assert.Error(t, err)
+	_, err = ParseDecimal32("1e3", 2)
+	assert.Error(t, err)
+	_, err = ParseDecimal32(".", 2)
+	assert.Error(t, err)
+	_, err = ParseDecimal32("21474836.48", 2)
+	assert.ErrorIs(t, err, ErrDecimalOverflow)
+	d32, err = ParseDecimal32("-21474836.48", 2)
+	require.NoError(t, err)
+	assert.Equal(t, Decimal32(math.MinInt32), d32)
+
+	s128 := "-1701411834604692317316873037.15884105728"
+	d128, err := ParseDecimal128(s128, 11)
+	require.NoError(t, err)
+	assert.Equal(t, s128, d128.String(11))
+	_, err = ParseDecimal128("1701411834604692317316873037.15884105728", 11)
+	assert.ErrorIs(t, err, ErrDecimalOverflow)
+
+	d256, err := ParseDecimal256("-123456789012345678901234567890.123456789", 9)
+	require.NoError(t, err)
+	assert.Equal(t, "-123456789012345678901234567890.123456789", d256.String(9))
+}
+
+func TestDecimalBig(t *testing.T) {
+	d64 := Decimal64(-12_345)
+	assert.Equal(t, big.NewRat(-12_345, 1000), d64.Rat(3))
+	d64, err := Decimal64FromRat(big.NewRat(1, 3), 4)
+	require.NoError(t, err)
+	assert.Equal(t, Decimal64(3333), d64)
+
+	d128, err := Decimal128FromRat(big.NewRat(-2, 3), 20)
+	require.NoError(t, err)
+	assert.Equal(t, "-0.66666666666666666666", d128.String(20))
+	assert.Equal(t, big.NewRat(-2, 3).Cmp(d128.Rat(20)), -1)
+
+	i, _ := new(big.Int).SetString("-57896044618658097711785492504343953926634992332820282019728792003956564819968", 10)
+	d256, err := Decimal256FromBig(i)
+	require.NoError(t, err)
+	assert.Equal(t, i, d256.Big())
+	_, err = Decimal256FromBig(new(big.Int).Neg(new(big.Int).Add(i, big.NewInt(1))))
+	require.NoError(t, err)
+	_, err = Decimal256FromBig(new(big.Int).Neg(i))
+	assert.ErrorIs(t, err, ErrDecimalOverflow)
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a, _ := ParseDecimal64("10.25", 2)
+	b, _ := ParseDecimal64("-3.10", 2)
+
+	v, err := a.Add(b)
+	require.NoError(t, err)
+	assert.Equal(t, "7.15", v.String(2))
+	v, err = a.Sub(b)
+	require.NoError(t, err)
+	assert.Equal(t, "13.35", v.String(2))
+	v, err = a.Mul(b, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "-31.77", v.String(2))
+	v, err = a.Div(b, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "-3.30", v.String(2))
+	_, err = a.Div(0, 2)
+	assert.ErrorIs(t, err, ErrDecimalDivisionByZero)
+	v, err = a.Rescale(2, 4)
+	require.NoError(t, err)
+	assert.Equal(t, "10.2500", v.String(4))
+	v, err = b.Rescale(2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "-3.1", v.String(1))
+	assert.Equal(t, 1, a.Cmp(b))
+
+	_, err = Decimal32(math.MaxInt32).Add(1)
+	assert.ErrorIs(t, err, ErrDecimalOverflow)
+
+	c, _ := ParseDecimal128("-1000000000000000000.000001", 6)
+	d, _ := ParseDecimal128("3", 6)
+	c128, err := c.Mul(d, 6)
+	require.NoError(t, err)
+	assert.Equal(t, "-3000000000000000000.000003", c128.String(6))
+	c128, err = c.Div(d, 6)
+	require.NoError(t, err)
+	assert.Equal(t, "-333333333333333333.333333", c128.String(6))
+
+	e, _ := ParseDecimal256("0.5", 3)
+	f, _ := ParseDecimal256("0.25", 3)
+	e256, err := e.Sub(f)
+	require.NoError(t, err)
+	assert.Equal(t, "0.250", e256.String(3))
+}
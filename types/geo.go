@@ -0,0 +1,597 @@
+package types
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Ring is the value of Ring ClickHouse data type (a closed polygon without holes)
+type Ring []Point
+
+// LineString is the value of LineString ClickHouse data type
+type LineString []Point
+
+// Polygon is the value of Polygon ClickHouse data type. the first ring is the outer ring and the others are holes.
+type Polygon [][]Point
+
+// MultiLineString is the value of MultiLineString ClickHouse data type
+type MultiLineString [][]Point
+
+// MultiPolygon is the value of MultiPolygon ClickHouse data type
+type MultiPolygon [][][]Point
+
+// WKB geometry types
+const (
+	wkbPoint           uint32 = 1
+	wkbLineString      uint32 = 2
+	wkbPolygon         uint32 = 3
+	wkbMultiLineString uint32 = 5
+	wkbMultiPolygon    uint32 = 6
+)
+
+var errInvalidWKB = errors.New("invalid WKB")
+
+// WKT return the well-known text representation of the point
+func (p Point) WKT() string {
+	b := append([]byte(nil), "POINT("...)
+	b = appendWKTPoint(b, p)
+	return string(append(b, ')'))
+}
+
+// WKB return the well-known binary representation (little endian) of the point
+func (p Point) WKB() []byte {
+	b := appendWKBHeader(nil, wkbPoint)
+	return appendWKBPoint(b, p)
+}
+
+// WKT return the well-known text representation of the ring. it is represented as a polygon the same as ClickHouse.
+func (r Ring) WKT() string {
+	return Polygon{r}.WKT()
+}
+
+// WKB return the well-known binary representation (little endian) of the ring as a polygon
+func (r Ring) WKB() []byte {
+	return Polygon{r}.WKB()
+}
+
+// WKT return the well-known text representation of the line string
+func (l LineString) WKT() string {
+	return string(appendWKTPoints(append([]byte(nil), "LINESTRING"...), l))
+}
+
+// WKB return the well-known binary representation (little endian) of the line string
+func (l LineString) WKB() []byte {
+	b := appendWKBHeader(nil, wkbLineString)
+	return appendWKBPoints(b, l)
+}
+
+// WKT return the well-known text representation of the polygon
+func (p Polygon) WKT() string {
+	return string(appendWKTPointsList(append([]byte(nil), "POLYGON"...), p))
+}
+
+// WKB return the well-known binary representation (little endian) of the polygon
+func (p Polygon) WKB() []byte {
+	return appendWKBPolygon(nil, p)
+}
+
+// WKT return the well-known text representation of the multi line string
+func (m MultiLineString) WKT() string {
+	return string(appendWKTPointsList(append([]byte(nil), "MULTILINESTRING"...), m))
+}
+
+// WKB return the well-known binary representation (little endian) of the multi line string
+func (m MultiLineString) WKB() []byte {
+	b := appendWKBHeader(nil, wkbMultiLineString)
+	b = appendUint32(b, uint32(len(m)))
+	for _, l := range m {
+		b = appendWKBHeader(b, wkbLineString)
+		b = appendWKBPoints(b, l)
+	}
+	return b
+}
+
+// WKT return the well-known text representation of the multi polygon
+func (m MultiPolygon) WKT() string {
+	b := append([]byte(nil), "MULTIPOLYGON"...)
+	if len(m) == 0 {
+		return string(append(b, " EMPTY"...))
+	}
+	b = append(b, '(')
+	for i, p := range m {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendWKTPointsList(b, p)
+	}
+	return string(append(b, ')'))
+}
+
+// WKB return the well-known binary representation (little endian) of the multi polygon
+func (m MultiPolygon) WKB() []byte {
+	b := appendWKBHeader(nil, wkbMultiPolygon)
+	b = appendUint32(b, uint32(len(m)))
+	for _, p := range m {
+		b = appendWKBPolygon(b, p)
+	}
+	return b
+}
+
+// PointFromWKT parse the well-known text representation of a point. e.g. POINT(1 2)
+func PointFromWKT(s string) (Point, error) {
+	p := &wktParser{s: s}
+	if err := p.tag("POINT"); err != nil {
+		return Point{}, err
+	}
+	if err := p.expect('('); err != nil {
+		return Point{}, err
+	}
+	point, err := p.point()
+	if err != nil {
+		return Point{}, err
+	}
+	if err := p.expect(')'); err != nil {
+		return Point{}, err
+	}
+	return point, p.end()
+}
+
+// RingFromWKT parse the well-known text representation of a ring. e.g. POLYGON((0 0, 1 0, 1 1, 0 0))
+func RingFromWKT(s string) (Ring, error) {
+	polygon, err := PolygonFromWKT(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(polygon) > 1 {
+		return nil, fmt.Errorf("invalid WKT: ring must not have holes")
+	}
+	if len(polygon) == 0 {
+		return Ring{}, nil
+	}
+	return polygon[0], nil
+}
+
+// LineStringFromWKT parse the well-known text representation of a line string. e.g. LINESTRING(0 0, 1 1)
+func LineStringFromWKT(s string) (LineString, error) {
+	p := &wktParser{s: s}
+	if err := p.tag("LINESTRING"); err != nil {
+		return nil, err
+	}
+	points, err := p.points()
+	if err != nil {
+		return nil, err
+	}
+	return points, p.end()
+}
+
+// PolygonFromWKT parse the well-known text representation of a polygon. e.g. POLYGON((0 0, 1 0, 1 1, 0 0))
+func PolygonFromWKT(s string) (Polygon, error) {
+	p := &wktParser{s: s}
+	if err := p.tag("POLYGON"); err != nil {
+		return nil, err
+	}
+	polygon, err := p.pointsList()
+	if err != nil {
+		return nil, err
+	}
+	return polygon, p.end()
+}
+
+// MultiLineStringFromWKT parse the well-known text representation of a multi line string.
+// e.g. MULTILINESTRING((0 0, 1 1), (2 2, 3 3))
+func MultiLineStringFromWKT(s string) (MultiLineString, error) {
+	p := &wktParser{s: s}
+	if err := p.tag("MULTILINESTRING"); err != nil {
+		return nil, err
+	}
+	lines, err := p.pointsList()
+	if err != nil {
+		return nil, err
+	}
+	return lines, p.end()
+}
+
+// MultiPolygonFromWKT parse the well-known text representation of a multi polygon.
+// e.g. MULTIPOLYGON(((0 0, 1 0, 1 1, 0 0)), ((2 2, 3 2, 3 3, 2 2)))
+func MultiPolygonFromWKT(s string) (MultiPolygon, error) {
+	p := &wktParser{s: s}
+	if err := p.tag("MULTIPOLYGON"); err != nil {
+		return nil, err
+	}
+	multiPolygon := MultiPolygon{}
+	if p.empty() {
+		return multiPolygon, p.end()
+	}
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	for {
+		polygon, err := p.pointsList()
+		if err != nil {
+			return nil, err
+		}
+		multiPolygon = append(multiPolygon, polygon)
+		if !p.next() {
+			break
+		}
+	}
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+	return multiPolygon, p.end()
+}
+
+// PointFromWKB parse the well-known binary representation of a point
+func PointFromWKB(b []byte) (Point, error) {
+	r := &wkbReader{b: b}
+	if err := r.header(wkbPoint); err != nil {
+		return Point{}, err
+	}
+	point := r.point()
+	return point, r.end()
+}
+
+// RingFromWKB parse the well-known binary representation of a ring (a polygon without holes)
+func RingFromWKB(b []byte) (Ring, error) {
+	polygon, err := PolygonFromWKB(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(polygon) > 1 {
+		return nil, fmt.Errorf("%w: ring must not have holes", errInvalidWKB)
+	}
+	if len(polygon) == 0 {
+		return Ring{}, nil
+	}
+	return polygon[0], nil
+}
+
+// LineStringFromWKB parse the well-known binary representation of a line string
+func LineStringFromWKB(b []byte) (LineString, error) {
+	r := &wkbReader{b: b}
+	if err := r.header(wkbLineString); err != nil {
+		return nil, err
+	}
+	points := r.points()
+	return points, r.end()
+}
+
+// PolygonFromWKB parse the well-known binary representation of a polygon
+func PolygonFromWKB(b []byte) (Polygon, error) {
+	r := &wkbReader{b: b}
+	polygon := r.polygon()
+	return polygon, r.end()
+}
+
+// MultiLineStringFromWKB parse the well-known binary representation of a multi line string
+func MultiLineStringFromWKB(b []byte) (MultiLineString, error) {
+	r := &wkbReader{b: b}
+	if err := r.header(wkbMultiLineString); err != nil {
+		return nil, err
+	}
+	n := r.uint32()
+	lines := make(MultiLineString, 0, r.capacity(n, 9))
+	for i := uint32(0); i < n && r.err == nil; i++ {
+		if err := r.header(wkbLineString); err != nil {
+			return nil, err
+		}
+		lines = append(lines, r.points())
+	}
+	return lines, r.end()
+}
+
+// MultiPolygonFromWKB parse the well-known binary representation of a multi polygon
+func MultiPolygonFromWKB(b []byte) (MultiPolygon, error) {
+	r := &wkbReader{b: b}
+	if err := r.header(wkbMultiPolygon); err != nil {
+		return nil, err
+	}
+	n := r.uint32()
+	multiPolygon := make(MultiPolygon, 0, r.capacity(n, 9))
+	for i := uint32(0); i < n && r.err == nil; i++ {
+		multiPolygon = append(multiPolygon, r.polygon())
+	}
+	return multiPolygon, r.end()
+}
+
+func appendWKTPoint(b []byte, p Point) []byte {
+	b = strconv.AppendFloat(b, p.Col1, 'f', -1, 64)
+	b = append(b, ' ')
+	return strconv.AppendFloat(b, p.Col2, 'f', -1, 64)
+}
+
+func appendWKTPoints(b []byte, points []Point) []byte {
+	if len(points) == 0 {
+		return append(b, " EMPTY"...)
+	}
+	b = append(b, '(')
+	for i, p := range points {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendWKTPoint(b, p)
+	}
+	return append(b, ')')
+}
+
+func appendWKTPointsList(b []byte, list [][]Point) []byte {
+	if len(list) == 0 {
+		return append(b, " EMPTY"...)
+	}
+	b = append(b, '(')
+	for i, points := range list {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendWKTPoints(b, points)
+	}
+	return append(b, ')')
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	return appendUint32(appendUint32(b, uint32(v)), uint32(v>>32))
+}
+
+func appendWKBHeader(b []byte, geometryType uint32) []byte {
+	// little endian
+	b = append(b, 1)
+	return appendUint32(b, geometryType)
+}
+
+func appendWKBPoint(b []byte, p Point) []byte {
+	b = appendUint64(b, math.Float64bits(p.Col1))
+	return appendUint64(b, math.Float64bits(p.Col2))
+}
+
+func appendWKBPoints(b []byte, points []Point) []byte {
+	b = appendUint32(b, uint32(len(points)))
+	for _, p := range points {
+		b = appendWKBPoint(b, p)
+	}
+	return b
+}
+
+func appendWKBPolygon(b []byte, polygon [][]Point) []byte {
+	b = appendWKBHeader(b, wkbPolygon)
+	b = appendUint32(b, uint32(len(polygon)))
+	for _, ring := range polygon {
+		b = appendWKBPoints(b, ring)
+	}
+	return b
+}
+
+// wktParser is a minimal parser of the well-known text representation
+type wktParser struct {
+	s   string
+	pos int
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t' || p.s[p.pos] == '\n' || p.s[p.pos] == '\r') {
+		p.pos++
+	}
+}
+
+func (p *wktParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid WKT at position %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *wktParser) tag(name string) error {
+	p.skipSpaces()
+	if len(p.s)-p.pos < len(name) || !strings.EqualFold(p.s[p.pos:p.pos+len(name)], name) {
+		return p.errorf("expected %s", name)
+	}
+	p.pos += len(name)
+	return nil
+}
+
+// empty consume the EMPTY keyword if exists
+func (p *wktParser) empty() bool {
+	p.skipSpaces()
+	if len(p.s)-p.pos >= 5 && strings.EqualFold(p.s[p.pos:p.pos+5], "EMPTY") {
+		p.pos += 5
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) expect(c byte) error {
+	p.skipSpaces()
+	if p.pos >= len(p.s) || p.s[p.pos] != c {
+		return p.errorf("expected %q", c)
+	}
+	p.pos++
+	return nil
+}
+
+// next consume the comma between the items and return false if there is no more item
+func (p *wktParser) next() bool {
+	p.skipSpaces()
+	if p.pos < len(p.s) && p.s[p.pos] == ',' {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) end() error {
+	p.skipSpaces()
+	if p.pos != len(p.s) {
+		return p.errorf("unexpected %q", p.s[p.pos:])
+	}
+	return nil
+}
+
+func (p *wktParser) number() (float64, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte("+-.0123456789eE", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+	f, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+	if err != nil {
+		p.pos = start
+		return 0, p.errorf("expected number")
+	}
+	return f, nil
+}
+
+func (p *wktParser) point() (Point, error) {
+	x, err := p.number()
+	if err != nil {
+		return Point{}, err
+	}
+	y, err := p.number()
+	if err != nil {
+		return Point{}, err
+	}
+	return Point{Col1: x, Col2: y}, nil
+}
+
+// points parse a list of points in parentheses. e.g. (0 0, 1 1)
+func (p *wktParser) points() ([]Point, error) {
+	points := []Point{}
+	if p.empty() {
+		return points, nil
+	}
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	for {
+		point, err := p.point()
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+		if !p.next() {
+			break
+		}
+	}
+	return points, p.expect(')')
+}
+
+// pointsList parse a list of lists of points in parentheses. e.g. ((0 0, 1 1), (2 2, 3 3))
+func (p *wktParser) pointsList() ([][]Point, error) {
+	list := [][]Point{}
+	if p.empty() {
+		return list, nil
+	}
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	for {
+		points, err := p.points()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, points)
+		if !p.next() {
+			break
+		}
+	}
+	return list, p.expect(')')
+}
+
+// wkbReader is a minimal reader of the well-known binary representation
+type wkbReader struct {
+	b     []byte
+	order binary.ByteOrder
+	err   error
+}
+
+func (r *wkbReader) read(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.b) < n {
+		r.err = fmt.Errorf("%w: unexpected end of data", errInvalidWKB)
+		return nil
+	}
+	v := r.b[:n]
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *wkbReader) header(geometryType uint32) error {
+	b := r.read(1)
+	if r.err != nil {
+		return r.err
+	}
+	switch b[0] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		r.err = fmt.Errorf("%w: invalid byte order %d", errInvalidWKB, b[0])
+		return r.err
+	}
+	if t := r.uint32(); r.err == nil && t != geometryType {
+		r.err = fmt.Errorf("%w: expected geometry type %d, got %d", errInvalidWKB, geometryType, t)
+	}
+	return r.err
+}
+
+func (r *wkbReader) uint32() uint32 {
+	b := r.read(4)
+	if r.err != nil {
+		return 0
+	}
+	return r.order.Uint32(b)
+}
+
+func (r *wkbReader) float64() float64 {
+	b := r.read(8)
+	if r.err != nil {
+		return 0
+	}
+	return math.Float64frombits(r.order.Uint64(b))
+}
+
+// capacity limit the capacity of the slices to the remaining data. (to avoid allocating for invalid data)
+func (r *wkbReader) capacity(n uint32, itemSize int) int {
+	if limit := len(r.b) / itemSize; int(n) > limit {
+		return limit
+	}
+	return int(n)
+}
+
+func (r *wkbReader) point() Point {
+	return Point{Col1: r.float64(), Col2: r.float64()}
+}
+
+func (r *wkbReader) points() []Point {
+	n := r.uint32()
+	points := make([]Point, 0, r.capacity(n, 16))
+	for i := uint32(0); i < n && r.err == nil; i++ {
+		points = append(points, r.point())
+	}
+	return points
+}
+
+func (r *wkbReader) polygon() [][]Point {
+	if err := r.header(wkbPolygon); err != nil {
+		return nil
+	}
+	n := r.uint32()
+	polygon := make([][]Point, 0, r.capacity(n, 4))
+	for i := uint32(0); i < n && r.err == nil; i++ {
+		polygon = append(polygon, r.points())
+	}
+	return polygon
+}
+
+func (r *wkbReader) end() error {
+	if r.err == nil && len(r.b) != 0 {
+		r.err = fmt.Errorf("%w: unexpected %d bytes at the end", errInvalidWKB, len(r.b))
+	}
+	return r.err
+}
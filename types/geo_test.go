@@ -0,0 +1,108 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeoWKT(t *testing.T) {
+	point := Point{Col1: 1.5, Col2: -2}
+	assert.Equal(t, "POINT(1.5 -2)", point.WKT())
+	pointParsed, err := PointFromWKT(" point ( 1.5  -2 ) ")
+	require.NoError(t, err)
+	assert.Equal(t, point, pointParsed)
+
+	ring := Ring{{Col1: 0, Col2: 0}, {Col1: 1, Col2: 0}, {Col1: 1, Col2: 1}, {Col1: 0, Col2: 0}}
+	assert.Equal(t, "POLYGON((0 0,1 0,1 1,0 0))", ring.WKT())
+	ringParsed, err := RingFromWKT(ring.WKT())
+	require.NoError(t, err)
+	assert.Equal(t, ring, ringParsed)
+
+	line := LineString{{Col1: 0, Col2: 0}, {Col1: 1e-3, Col2: 10}}
+	assert.Equal(t, "LINESTRING(0 0,0.001 10)", line.WKT())
+	lineParsed, err := LineStringFromWKT("LINESTRING (0 0, 1e-3 10)")
+	require.NoError(t, err)
+	assert.Equal(t, line, lineParsed)
+
+	polygon := Polygon{ring, {{Col1: 0.2, Col2: 0.2}, {Col1: 0.5, Col2: 0.2}, {Col1: 0.2, Col2: 0.2}}}
+	assert.Equal(t, "POLYGON((0 0,1 0,1 1,0 0),(0.2 0.2,0.5 0.2,0.2 0.2))", polygon.WKT())
+	polygonParsed, err := PolygonFromWKT(polygon.WKT())
+	require.NoError(t, err)
+	assert.Equal(t, polygon, polygonParsed)
+
+	multiLine := MultiLineString{line, {}}
+	assert.Equal(t, "MULTILINESTRING((0 0,0.001 10), EMPTY)", multiLine.WKT())
+	multiLineParsed, err := MultiLineStringFromWKT(multiLine.WKT())
+	require.NoError(t, err)
+	assert.Equal(t, multiLine, multiLineParsed)
+
+	multiPolygon := MultiPolygon{polygon, {ring}}
+	multiPolygonParsed, err := MultiPolygonFromWKT(multiPolygon.WKT())
+	require.NoError(t, err)
+	assert.Equal(t, multiPolygon, multiPolygonParsed)
+
+	assert.Equal(t, "MULTIPOLYGON EMPTY", MultiPolygon{}.WKT())
+	multiPolygonParsed, err = MultiPolygonFromWKT("MULTIPOLYGON EMPTY")
+	require.NoError(t, err)
+	assert.Equal(t, MultiPolygon{}, multiPolygonParsed)
+
+	_, err = PolygonFromWKT("POLYGON((0 0, 1))")
+	assert.Error(t, err)
+	_, err = RingFromWKT(polygon.WKT())
+	assert.Error(t, err)
+	_, err = PointFromWKT("POINT(1 2) x")
+	assert.Error(t, err)
+}
+
+func TestGeoWKB(t *testing.T) {
+	point := Point{Col1: 1, Col2: 2}
+	assert.Equal(t, []byte{
+		1, 1, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0xf0, 0x3f,
+		0, 0, 0, 0, 0, 0, 0, 0x40,
+	}, point.WKB())
+	pointParsed, err := PointFromWKB(point.WKB())
+	require.NoError(t, err)
+	assert.Equal(t, point, pointParsed)
+
+	// big endian
+	pointParsed, err = PointFromWKB([]byte{
+		0, 0, 0, 0, 1,
+		0x3f, 0xf0, 0, 0, 0, 0, 0, 0,
+		0x40, 0, 0, 0, 0, 0, 0, 0,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, point, pointParsed)
+
+	ring := Ring{{Col1: 0, Col2: 0}, {Col1: 1, Col2: 0}, {Col1: 1, Col2: 1}, {Col1: 0, Col2: 0}}
+	ringParsed, err := RingFromWKB(ring.WKB())
+	require.NoError(t, err)
+	assert.Equal(t, ring, ringParsed)
+
+	line := LineString{{Col1: 0, Col2: 0}, {Col1: 3, Col2: 4}}
+	lineParsed, err := LineStringFromWKB(line.WKB())
+	require.NoError(t, err)
+	assert.Equal(t, line, lineParsed)
+
+	polygon := Polygon{ring, {{Col1: 0.2, Col2: 0.2}, {Col1: 0.5, Col2: 0.2}, {Col1: 0.2, Col2: 0.2}}}
+	polygonParsed, err := PolygonFromWKB(polygon.WKB())
+	require.NoError(t, err)
+	assert.Equal(t, polygon, polygonParsed)
+
+	multiLine := MultiLineString{line, {}}
+	multiLineParsed, err := MultiLineStringFromWKB(multiLine.WKB())
+	require.NoError(t, err)
+	assert.Equal(t, multiLine, multiLineParsed)
+
+	multiPolygon := MultiPolygon{polygon, {ring}}
+	multiPolygonParsed, err := MultiPolygonFromWKB(multiPolygon.WKB())
+	require.NoError(t, err)
+	assert.Equal(t, multiPolygon, multiPolygonParsed)
+
+	_, err = PolygonFromWKB(polygon.WKB()[:20])
+	assert.ErrorIs(t, err, errInvalidWKB)
+	_, err = LineStringFromWKB(polygon.WKB())
+	assert.ErrorIs(t, err, errInvalidWKB)
+}
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"time"
+)
+
+// Time is the raw value of Time ClickHouse data type (number of seconds)
+type Time int32
+
+// Time64 is the raw value of Time64(P) ClickHouse data type (number of ticks with the precision P)
+type Time64 int64
+
+// Interval is the raw value of Interval* ClickHouse data types (number of the interval units)
+type Interval int64
+
+func (t Time) FromDuration(v, unit time.Duration) Time {
+	return Time(v / unit)
+}
+
+func (t Time) ToDuration(unit time.Duration) time.Duration {
+	return time.Duration(t) * unit
+}
+
+func (t Time64) FromDuration(v, unit time.Duration) Time64 {
+	return Time64(v / unit)
+}
+
+func (t Time64) ToDuration(unit time.Duration) time.Duration {
+	return time.Duration(t) * unit
+}
+
+func (t Interval) FromDuration(v, unit time.Duration) Interval {
+	return Interval(v / unit)
+}
+
+func (t Interval) ToDuration(unit time.Duration) time.Duration {
+	return time.Duration(t) * unit
+}
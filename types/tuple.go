@@ -27,3 +27,58 @@ type Tuple5[T1, T2, T3, T4, T5 any] struct {
 	Col4 T4
 	Col5 T5
 }
+
+type Tuple6[T1, T2, T3, T4, T5, T6 any] struct {
+	Col1 T1
+	Col2 T2
+	Col3 T3
+	Col4 T4
+	Col5 T5
+	Col6 T6
+}
+
+type Tuple7[T1, T2, T3, T4, T5, T6, T7 any] struct {
+	Col1 T1
+	Col2 T2
+	Col3 T3
+	Col4 T4
+	Col5 T5
+	Col6 T6
+	Col7 T7
+}
+
+type Tuple8[T1, T2, T3, T4, T5, T6, T7, T8 any] struct {
+	Col1 T1
+	Col2 T2
+	Col3 T3
+	Col4 T4
+	Col5 T5
+	Col6 T6
+	Col7 T7
+	Col8 T8
+}
+
+type Tuple9[T1, T2, T3, T4, T5, T6, T7, T8, T9 any] struct {
+	Col1 T1
+	Col2 T2
+	Col3 T3
+	Col4 T4
+	Col5 T5
+	Col6 T6
+	Col7 T7
+	Col8 T8
+	Col9 T9
+}
+
+type Tuple10[T1, T2, T3, T4, T5, T6, T7, T8, T9, T10 any] struct {
+	Col1  T1
+	Col2  T2
+	Col3  T3
+	Col4  T4
+	Col5  T5
+	Col6  T6
+	Col7  T7
+	Col8  T8
+	Col9  T9
+	Col10 T10
+}